@@ -21,14 +21,20 @@ else
     exit 1
 fi
 
-# Check pdflatex
-echo -n "Checking pdflatex installation... "
-if command -v pdflatex &> /dev/null; then
-    echo -e "${GREEN}✓${NC} Found"
+# Check TeX engines
+echo -n "Checking TeX engines... "
+ENGINES=""
+for engine in xelatex lualatex pdflatex tectonic; do
+    if command -v "$engine" &> /dev/null; then
+        ENGINES="$ENGINES $engine"
+    fi
+done
+if [ -n "$ENGINES" ]; then
+    echo -e "${GREEN}✓${NC} Found:$ENGINES"
 else
-    echo -e "${YELLOW}⚠${NC} pdflatex not found (required for PDF generation)"
+    echo -e "${YELLOW}⚠${NC} No TeX engine found (PDFs will use the native renderer instead of the LaTeX templates)"
     echo "  macOS: brew install --cask mactex-no-gui"
-    echo "  Linux: sudo apt-get install texlive-latex-base texlive-latex-extra"
+    echo "  Linux: sudo apt-get install texlive-xetex texlive-latex-extra"
 fi
 
 # Check resume.yaml
@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// docxRightTab is the position of the right-aligned tab stop for dates and
+// locations, in twentieths of a point: letter width minus half-inch margins
+const docxRightTab = 12240 - 2*720
+
+// docxWriter builds the body of word/document.xml
+type docxWriter struct {
+	body bytes.Buffer
+	// links holds hyperlink targets; link i has relationship ID "rIdLink<i+1>"
+	links []string
+}
+
+// docxRun is the XML of one run (or hyperlink) within a paragraph
+type docxRun string
+
+func docxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func docxText(s string) docxRun {
+	return docxRun(`<w:r><w:t xml:space="preserve">` + docxEscape(s) + `</w:t></w:r>`)
+}
+
+func docxBold(s string) docxRun {
+	return docxRun(`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">` + docxEscape(s) + `</w:t></w:r>`)
+}
+
+func docxItalic(s string) docxRun {
+	return docxRun(`<w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">` + docxEscape(s) + `</w:t></w:r>`)
+}
+
+// docxTab moves the following runs to the right-aligned tab stop
+const docxTab docxRun = `<w:r><w:tab/></w:r>`
+
+// link returns a hyperlink run, adding a scheme to url if it has none
+func (w *docxWriter) link(text, url string) docxRun {
+	if !strings.HasPrefix(url, "mailto:") {
+		url = href(url)
+	}
+	w.links = append(w.links, url)
+	return docxRun(fmt.Sprintf(`<w:hyperlink r:id="rIdLink%d"><w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t xml:space="preserve">%s</w:t></w:r></w:hyperlink>`,
+		len(w.links), docxEscape(text)))
+}
+
+// para writes a paragraph in style
+func (w *docxWriter) para(style string, runs ...docxRun) {
+	fmt.Fprintf(&w.body, `<w:p><w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	for _, run := range runs {
+		w.body.WriteString(string(run))
+	}
+	w.body.WriteString("</w:p>")
+}
+
+// row writes a paragraph with left text and, if set, right-aligned text
+func (w *docxWriter) row(style string, left []docxRun, right docxRun) {
+	if right != "" {
+		left = append(left, docxTab, right)
+	}
+	w.para(style, left...)
+}
+
+func (w *docxWriter) bullets(items []string) {
+	for _, item := range items {
+		w.para("ListBullet", docxText(item))
+	}
+}
+
+// separated joins the non-empty runs with " | "
+func separated(runs ...docxRun) []docxRun {
+	var out []docxRun
+	for _, run := range runs {
+		if run == "" {
+			continue
+		}
+		if len(out) > 0 {
+			out = append(out, docxText(" | "))
+		}
+		out = append(out, run)
+	}
+	return out
+}
+
+// optional returns run(s), or no run if s is empty
+func optional(run func(string) docxRun, s string) docxRun {
+	if s == "" {
+		return ""
+	}
+	return run(s)
+}
+
+// renderDOCX renders a Word document with Word's own heading and list
+// styles, so the result stays editable in Word and readable to parsers
+func renderDOCX(data TemplateData, _ Options) ([]byte, error) {
+	w := &docxWriter{}
+	c := data.Contact
+	w.para("Title", docxText(c.Name))
+	var contact []docxRun
+	contact = append(contact, optional(docxText, c.Location), optional(docxText, c.Phone))
+	if c.Email != "" {
+		contact = append(contact, w.link(c.Email, "mailto:"+c.Email))
+	}
+	if c.LinkedIn != "" {
+		contact = append(contact, w.link(c.LinkedIn, c.LinkedIn))
+	}
+	if c.GitHub != "" {
+		contact = append(contact, w.link(c.GitHub, c.GitHub))
+	}
+	w.para("Contact", separated(contact...)...)
+
+	for _, section := range data.Sections {
+		w.para("Heading1", docxText(section.Title))
+		if section.Custom != nil {
+			writeDOCXCustom(w, section.Custom)
+			continue
+		}
+		switch section.Name {
+		case resume.SectionSummary:
+			w.para("Normal", docxText(data.Summary))
+		case resume.SectionEducation:
+			for _, edu := range data.Education {
+				w.row("Heading2", []docxRun{docxText(edu.Institution)}, optional(docxText, edu.Location))
+				w.row("Subheading", []docxRun{docxText(degreeLine(edu))}, optional(docxText, dateRange(edu.StartDate, edu.EndDate, " – ")))
+				details := educationDetails(edu)
+				if len(edu.Coursework) > 0 {
+					details = append(details, "Coursework: "+strings.Join(edu.Coursework, ", "))
+				}
+				w.bullets(details)
+			}
+		case resume.SectionSkills:
+			for _, category := range data.Skills {
+				w.para("Normal", docxBold(category.Label+": "), docxText(strings.Join(category.Skills, ", ")))
+			}
+		case resume.SectionExperience:
+			for _, exp := range data.Experience {
+				if len(exp.Positions) > 1 {
+					w.row("Heading2", []docxRun{docxText(exp.Company)}, optional(docxText, exp.Location))
+					for _, pos := range exp.Positions {
+						title := joinNonEmpty(", ", pos.Title, positionLocation(exp, pos))
+						w.row("Subheading", []docxRun{docxText(title)}, docxText(dateRange(pos.StartDate, pos.EndDate, " – ")))
+						w.bullets(pos.Bullets)
+					}
+					continue
+				}
+				w.row("Heading2", []docxRun{docxText(exp.Company)}, docxText(dateRange(exp.StartDate, exp.EndDate, " – ")))
+				w.row("Subheading", []docxRun{docxText(exp.Title)}, optional(docxText, exp.Location))
+				w.bullets(exp.Bullets)
+			}
+		case resume.SectionProjects:
+			for _, proj := range data.Projects {
+				var right docxRun
+				if proj.GitHub != "" {
+					right = w.link(proj.GitHub, proj.GitHub)
+				}
+				w.row("Heading2", separated(docxText(proj.Title), optional(docxItalic, proj.Technologies)), right)
+				w.bullets(proj.Bullets)
+			}
+		case resume.SectionLeadership:
+			w.bullets(data.Leadership)
+		}
+	}
+
+	return w.archive(c.Name)
+}
+
+func writeDOCXCustom(w *docxWriter, section *CustomSectionData) {
+	switch section.Layout {
+	case resume.LayoutEntries:
+		for _, item := range section.Items {
+			w.row("Heading2", separated(docxText(item.Title), optional(docxItalic, item.Subtitle)), optional(docxText, item.Date))
+			if item.Text != "" {
+				w.bullets([]string{item.Text})
+			}
+		}
+	case resume.LayoutTable:
+		for _, item := range section.Items {
+			w.para("Normal", docxBold(item.Key+": "), docxText(item.Value))
+		}
+	default:
+		for _, item := range section.Items {
+			w.para("ListBullet", docxText(item.Text))
+		}
+	}
+}
+
+// archive packages the body with the fixed parts of a .docx file. Entries
+// carry no timestamps, so the same resume always produces the same bytes.
+func (w *docxWriter) archive(title string) ([]byte, error) {
+	var rels strings.Builder
+	rels.WriteString(xml.Header)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	rels.WriteString(`<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	rels.WriteString(`<Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>`)
+	for i, url := range w.links {
+		fmt.Fprintf(&rels, `<Relationship Id="rIdLink%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`,
+			i+1, docxEscape(url))
+	}
+	rels.WriteString(`</Relationships>`)
+
+	document := xml.Header +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>` +
+		w.body.String() +
+		`<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="720" w:right="720" w:bottom="720" w:left="720" w:header="0" w:footer="0" w:gutter="0"/></w:sectPr>` +
+		`</w:body></w:document>`
+
+	core := xml.Header +
+		`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<dc:title>` + docxEscape(title) + `</dc:title><dc:creator>` + docxEscape(title) + `</dc:creator></cp:coreProperties>`
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", core},
+		{"word/document.xml", document},
+		{"word/_rels/document.xml.rels", rels.String()},
+		{"word/styles.xml", docxStyles},
+		{"word/numbering.xml", docxNumbering},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range parts {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: part.name, Method: zip.Deflate})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+		if _, err := f.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write DOCX archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+const docxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxPackageRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// docxNumbering defines the bullet list used by the ListBullet style
+const docxNumbering = xml.Header + `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:abstractNum w:abstractNumId="0"><w:multiLevelType w:val="singleLevel"/>` +
+	`<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/>` +
+	`<w:pPr><w:ind w:left="540" w:hanging="220"/></w:pPr></w:lvl></w:abstractNum>` +
+	`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>` +
+	`</w:numbering>`
+
+// docxStyles mirrors the modern LaTeX template: a sans-serif face, small-caps
+// ruled section headings and right-aligned dates. Styles use Word's built-in
+// names so headings show up in the navigation pane and outline.
+var docxStyles = xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:eastAsia="Arial" w:cs="Arial"/><w:sz w:val="20"/><w:szCs w:val="20"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Contact"/><w:qFormat/>` +
+	`<w:pPr><w:jc w:val="center"/></w:pPr><w:rPr><w:b/><w:smallCaps/><w:sz w:val="48"/><w:szCs w:val="48"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Contact"><w:name w:val="Contact"/><w:basedOn w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:spacing w:after="120"/><w:jc w:val="center"/></w:pPr><w:rPr><w:sz w:val="18"/><w:szCs w:val="18"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="000000"/></w:pBdr><w:spacing w:before="200" w:after="80"/><w:outlineLvl w:val="0"/></w:pPr>` +
+	`<w:rPr><w:smallCaps/><w:sz w:val="26"/><w:szCs w:val="26"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Subheading"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:tabs><w:tab w:val="right" w:pos="` + fmt.Sprint(docxRightTab) + `"/></w:tabs><w:spacing w:before="80"/><w:ind w:left="200"/><w:outlineLvl w:val="1"/></w:pPr>` +
+	`<w:rPr><w:b/><w:sz w:val="21"/><w:szCs w:val="21"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Subheading"><w:name w:val="Subheading"/><w:basedOn w:val="Normal"/><w:next w:val="ListBullet"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:tabs><w:tab w:val="right" w:pos="` + fmt.Sprint(docxRightTab) + `"/></w:tabs><w:ind w:left="200"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:numPr><w:numId w:val="1"/></w:numPr><w:spacing w:after="20"/></w:pPr></w:style>` +
+	`<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="1A4F9C"/><w:u w:val="single"/></w:rPr></w:style>` +
+	`</w:styles>`
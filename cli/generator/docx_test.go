@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readDOCX unzips a .docx file into its parts, checking each XML part is well formed
+func readDOCX(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", f.Name, err)
+			}
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestRenderDOCX(t *testing.T) {
+	r := formatTestResume()
+	r.Contact.Name = "Test & User"
+
+	out, err := Render(FormatDOCX, r, map[string]bool{"acme-2": true, "tool-1": true}, Options{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	parts := readDOCX(t, out)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/_rels/document.xml.rels", "word/styles.xml", "word/numbering.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	doc := parts["word/document.xml"]
+	for _, want := range []string{
+		`<w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">Test &amp; User</w:t>`,
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Experience</w:t>`,
+		`<w:pStyle w:val="ListBullet"/></w:pPr><w:r><w:t xml:space="preserve">Built *fast* services</w:t>`,
+		`<w:hyperlink r:id="rIdLink1">`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document.xml missing %q", want)
+		}
+	}
+	if strings.Contains(doc, "Senior Engineer") {
+		t.Error("unselected position should not be rendered")
+	}
+
+	rels := parts["word/_rels/document.xml.rels"]
+	for _, want := range []string{
+		`Id="rIdLink1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="mailto:test@example.com" TargetMode="External"`,
+		`Target="https://linkedin.com/in/test"`,
+		`Target="https://github.com/test/tool"`,
+	} {
+		if !strings.Contains(rels, want) {
+			t.Errorf("document.xml.rels missing %q", want)
+		}
+	}
+	if strings.Count(doc, "<w:hyperlink ") != strings.Count(rels, "hyperlink") {
+		t.Error("every hyperlink needs a relationship")
+	}
+
+	again, err := Render(FormatDOCX, r, map[string]bool{"acme-2": true, "tool-1": true}, Options{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("DOCX output should be deterministic")
+	}
+}
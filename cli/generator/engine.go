@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PDF engines
+const (
+	EngineXelatex  = "xelatex"
+	EngineLualatex = "lualatex"
+	EnginePdflatex = "pdflatex"
+	EngineTectonic = "tectonic"
+	EngineNative   = "native"
+)
+
+// Engines lists the PDF engines
+var Engines = []string{EngineXelatex, EngineLualatex, EnginePdflatex, EngineTectonic, EngineNative}
+
+// EngineEnv names the environment variable that selects the PDF engine
+// when none is given explicitly
+const EngineEnv = "RESUME_PDF_ENGINE"
+
+// TeXEngine is a program that compiles LaTeX to PDF
+type TeXEngine interface {
+	// Name is the engine name accepted by --engine and template metadata
+	Name() string
+	// Find locates the engine's executable
+	Find() (string, error)
+	// Args returns the arguments that compile texFile, writing output to outDir
+	Args(texFile, outDir string) []string
+	// Passes is the most times the engine is run. Passes after the first
+	// only happen while the labels in the .aux file are still changing.
+	Passes() int
+	// Unicode reports whether the engine supports fontspec and system fonts
+	Unicode() bool
+}
+
+var texEngines = map[string]TeXEngine{
+	EngineXelatex:  latexEngine{name: EngineXelatex, unicode: true},
+	EngineLualatex: latexEngine{name: EngineLualatex, unicode: true},
+	EnginePdflatex: latexEngine{name: EnginePdflatex},
+	EngineTectonic: tectonicEngine{},
+}
+
+// defaultEngines are tried in order when neither the caller nor the template
+// picks an engine. The built-in templates use fontspec, which pdflatex lacks.
+var defaultEngines = []string{EngineXelatex, EngineLualatex, EngineTectonic}
+
+// LookupTeXEngine returns the TeX engine with the given name
+func LookupTeXEngine(name string) (TeXEngine, error) {
+	engine, ok := texEngines[name]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a TeX engine", name)
+	}
+	return engine, nil
+}
+
+// ResolveEngine returns the engine to use for name. An empty name falls back
+// to the engine t declares, then to $RESUME_PDF_ENGINE, then to the first
+// installed of xelatex, lualatex and tectonic. A template's engine wins over
+// the environment because the template may not build with any other; if it
+// is not installed, or no TeX engine is, the native renderer is used.
+// Engines that cannot run t are rejected. t may be nil.
+func ResolveEngine(name string, t *Template) (string, error) {
+	if name == "" {
+		env := os.Getenv(EngineEnv)
+		if env != "" && !isEngine(env) {
+			return "", fmt.Errorf("%s: unknown engine %q (available: %s)", EngineEnv, env, strings.Join(Engines, ", "))
+		}
+		if t != nil && t.Engine != "" {
+			if _, err := texEngines[t.Engine].Find(); err != nil {
+				return EngineNative, nil
+			}
+			return t.Engine, nil
+		}
+		name = env
+	}
+	if name != "" {
+		if !isEngine(name) {
+			return "", fmt.Errorf("unknown engine %q (available: %s)", name, strings.Join(Engines, ", "))
+		}
+		if engine, ok := texEngines[name]; ok && t != nil && t.fontspec && !engine.Unicode() {
+			return "", fmt.Errorf("template %s uses fontspec, which %s does not support; use xelatex, lualatex or tectonic", t.Name, name)
+		}
+		return name, nil
+	}
+
+	for _, candidate := range defaultEngines {
+		if _, err := texEngines[candidate].Find(); err == nil {
+			return candidate, nil
+		}
+	}
+	return EngineNative, nil
+}
+
+func isEngine(name string) bool {
+	for _, engine := range Engines {
+		if name == engine {
+			return true
+		}
+	}
+	return false
+}
+
+// latexEngine is a TeX Live or MiKTeX program such as xelatex
+type latexEngine struct {
+	name    string
+	unicode bool
+}
+
+func (e latexEngine) Name() string { return e.name }
+
+func (e latexEngine) Find() (string, error) {
+	if path, ok := findExecutable(e.name, texSearchDirs); ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("%s not found. Install LaTeX (e.g., 'brew install --cask mactex' on macOS or the texlive packages on Linux)", e.name)
+}
+
+func (e latexEngine) Args(texFile, outDir string) []string {
+	return []string{"-interaction=nonstopmode", "-output-directory=" + outDir, texFile}
+}
+
+func (e latexEngine) Passes() int { return 3 }
+
+func (e latexEngine) Unicode() bool { return e.unicode }
+
+// tectonicEngine is the self-contained Tectonic engine, which fetches
+// packages on demand and reruns itself until the document is stable
+type tectonicEngine struct{}
+
+func (tectonicEngine) Name() string { return EngineTectonic }
+
+func (tectonicEngine) Find() (string, error) {
+	dirs := []string{"/opt/homebrew/bin", "/usr/local/bin"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".cargo", "bin"))
+	}
+	if path, ok := findExecutable(EngineTectonic, dirs); ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("tectonic not found. Install it (e.g., 'brew install tectonic' or 'cargo install tectonic')")
+}
+
+// Args keeps the log so the page count can be read from it
+func (tectonicEngine) Args(texFile, outDir string) []string {
+	return []string{"--outdir", outDir, "--keep-logs", "--chatter", "minimal", texFile}
+}
+
+func (tectonicEngine) Passes() int { return 1 }
+
+func (tectonicEngine) Unicode() bool { return true }
+
+// texSearchDirs are checked for TeX programs that are not on PATH. Globs
+// cover every TeX Live release and platform directory.
+var texSearchDirs = []string{
+	"/Library/TeX/texbin",        // MacTeX on macOS
+	"/usr/local/texlive/*/bin/*", // TeX Live installer
+	"/opt/texlive/*/bin/*",       // TeX Live installer, alternate prefix
+	"/opt/homebrew/bin",          // Homebrew on Apple Silicon
+	"/usr/local/bin",             // Homebrew on Intel Macs
+	"/usr/bin",                   // System packages
+}
+
+// findExecutable looks for name on PATH and then in dirs, preferring the
+// newest match when a glob matches several TeX Live releases
+func findExecutable(name string, dirs []string) (string, bool) {
+	if path, err := exec.LookPath(name); err == nil {
+		return path, true
+	}
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, name))
+		sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+		for _, path := range matches {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CompileTeX compiles texFile with engine, writing the PDF and intermediate
+// files beside it, and returns the page count
+func CompileTeX(engine TeXEngine, texFile string) (int, error) {
+	path, err := engine.Find()
+	if err != nil {
+		return 0, err
+	}
+	absTexFile, err := filepath.Abs(texFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	outDir := filepath.Dir(absTexFile)
+	base := strings.TrimSuffix(absTexFile, filepath.Ext(absTexFile))
+
+	var output []byte
+	labels := auxLabels(base + ".aux")
+	for pass := 0; pass < engine.Passes(); pass++ {
+		cmd := exec.Command(path, engine.Args(absTexFile, outDir)...)
+		cmd.Dir = outDir
+		output, err = cmd.CombinedOutput()
+		if err != nil {
+			return 0, fmt.Errorf("%s failed: %w\nOutput: %s", engine.Name(), err, string(output))
+		}
+		previous := labels
+		labels = auxLabels(base + ".aux")
+		if labels == previous {
+			break
+		}
+	}
+
+	// The log has the summary line for every engine; tectonic does not print it
+	if logData, err := os.ReadFile(base + ".log"); err == nil {
+		if pages := PageCount(string(logData)); pages > 0 {
+			return pages, nil
+		}
+	}
+	return PageCount(string(output)), nil
+}
+
+// auxLabels returns the \newlabel lines of an .aux file, or "" if it is missing
+func auxLabels(auxFile string) string {
+	data, err := os.ReadFile(auxFile)
+	if err != nil {
+		return ""
+	}
+	var labels []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, `\newlabel`) {
+			labels = append(labels, line)
+		}
+	}
+	return strings.Join(labels, "\n")
+}
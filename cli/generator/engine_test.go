@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeEngine runs a shell script in place of a TeX program
+type fakeEngine struct {
+	script string
+}
+
+func (e fakeEngine) Name() string                         { return "fake" }
+func (e fakeEngine) Find() (string, error)                { return e.script, nil }
+func (e fakeEngine) Args(texFile, outDir string) []string { return []string{texFile} }
+func (e fakeEngine) Passes() int                          { return 5 }
+func (e fakeEngine) Unicode() bool                        { return true }
+
+// writeScript writes an executable shell script to dir and returns its path
+func writeScript(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on Windows")
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestResolveEngine(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(EngineEnv, "")
+
+	for _, name := range Engines {
+		if got, err := ResolveEngine(name, nil); err != nil || got != name {
+			t.Errorf("ResolveEngine(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ResolveEngine("troff", nil); err == nil {
+		t.Error("expected error for unknown engine")
+	}
+
+	t.Setenv(EngineEnv, EngineTectonic)
+	if got, err := ResolveEngine("", nil); err != nil || got != EngineTectonic {
+		t.Errorf("ResolveEngine with %s set = %q, %v", EngineEnv, got, err)
+	}
+	if got, _ := ResolveEngine(EngineNative, nil); got != EngineNative {
+		t.Errorf("the flag should override %s, got %q", EngineEnv, got)
+	}
+	t.Setenv(EngineEnv, "troff")
+	if _, err := ResolveEngine("", nil); err == nil {
+		t.Errorf("expected error for unknown engine in %s", EngineEnv)
+	}
+	t.Setenv(EngineEnv, "")
+
+	// A template whose engine is missing falls back to native, and fontspec
+	// rules out pdflatex
+	tmpl := &Template{Name: "lua", Engine: EngineLualatex}
+	if got, err := ResolveEngine("", tmpl); err != nil || got != EngineNative {
+		t.Errorf("ResolveEngine for a missing template engine = %q, %v", got, err)
+	}
+	modern, err := NewRegistry().Get("modern")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveEngine(EnginePdflatex, modern); err == nil || !strings.Contains(err.Error(), "fontspec") {
+		t.Errorf("expected pdflatex to be rejected for a fontspec template, got %v", err)
+	}
+	if got, err := ResolveEngine(EngineLualatex, modern); err != nil || got != EngineLualatex {
+		t.Errorf("ResolveEngine(lualatex, modern) = %q, %v", got, err)
+	}
+
+	bin := t.TempDir()
+	writeScript(t, bin, EngineLualatex, "exit 0\n")
+	t.Setenv("PATH", bin)
+	if got, err := ResolveEngine("", tmpl); err != nil || got != EngineLualatex {
+		t.Errorf("ResolveEngine for a lualatex template = %q, %v", got, err)
+	}
+	if got, err := ResolveEngine("", nil); err != nil || got != EngineLualatex {
+		t.Errorf("ResolveEngine with only lualatex installed = %q, %v", got, err)
+	}
+
+	// The template's engine wins over the environment, and the flag over both
+	writeScript(t, bin, EngineXelatex, "exit 0\n")
+	t.Setenv(EngineEnv, EngineXelatex)
+	if got, err := ResolveEngine("", tmpl); err != nil || got != EngineLualatex {
+		t.Errorf("ResolveEngine for a lualatex template with %s=xelatex = %q, %v", EngineEnv, got, err)
+	}
+	if got, err := ResolveEngine("", nil); err != nil || got != EngineXelatex {
+		t.Errorf("ResolveEngine without a template engine = %q, want %s from %s: %v", got, EngineXelatex, EngineEnv, err)
+	}
+	if got, err := ResolveEngine(EngineTectonic, tmpl); err != nil || got != EngineTectonic {
+		t.Errorf("the flag should override the template, got %q, %v", got, err)
+	}
+}
+
+func TestTemplateEngine(t *testing.T) {
+	tmpl, err := parseTemplate("lua", "% description: LuaTeX layout\n% engine: lualatex\n\\documentclass{article}\n")
+	if err != nil {
+		t.Fatalf("parseTemplate failed: %v", err)
+	}
+	if tmpl.Engine != EngineLualatex {
+		t.Errorf("Engine = %q, want %q", tmpl.Engine, EngineLualatex)
+	}
+	if _, err := parseTemplate("bad", "% engine: native\n\\documentclass{article}\n"); err == nil {
+		t.Error("expected error for a template requiring a non-TeX engine")
+	}
+	if _, err := parseTemplate("bad", "% engine: pdflatex\n\\documentclass{article}\n\\usepackage[no-math]{fontspec}\n"); err == nil {
+		t.Error("expected error for a fontspec template requiring pdflatex")
+	}
+	for _, tmpl := range NewRegistry().List() {
+		if tmpl.Engine != "" {
+			t.Errorf("built-in template %q should not require an engine", tmpl.Name)
+		}
+		if !tmpl.fontspec {
+			t.Errorf("built-in template %q should be detected as using fontspec", tmpl.Name)
+		}
+	}
+}
+
+func TestCompileTeX(t *testing.T) {
+	// The script counts its passes and writes a label that settles on the second
+	script := writeScript(t, t.TempDir(), "fake", `base="${1%.tex}"
+echo pass >> "$base.passes"
+n=$(wc -l < "$base.passes" | tr -d ' ')
+if [ "$LABELS" = yes ]; then
+	[ "$n" -gt 2 ] && n=2
+	printf '\\relax\n\\newlabel{last}{{%s}{1}}\n' "$n" > "$base.aux"
+else
+	printf '\\relax\n' > "$base.aux"
+fi
+printf 'Output written on %s.pdf (2 pages, 1024 bytes).\n' "$base" > "$base.log"
+`)
+
+	for _, tt := range []struct {
+		labels string
+		passes int
+	}{
+		{"yes", 3},
+		{"no", 1},
+	} {
+		t.Setenv("LABELS", tt.labels)
+		texFile := filepath.Join(t.TempDir(), "resume.tex")
+		if err := os.WriteFile(texFile, []byte("\\documentclass{article}\n"), 0644); err != nil {
+			t.Fatalf("failed to write tex file: %v", err)
+		}
+
+		pages, err := CompileTeX(fakeEngine{script: script}, texFile)
+		if err != nil {
+			t.Fatalf("CompileTeX failed: %v", err)
+		}
+		if pages != 2 {
+			t.Errorf("labels=%s: got %d pages, want 2", tt.labels, pages)
+		}
+		data, err := os.ReadFile(strings.TrimSuffix(texFile, ".tex") + ".passes")
+		if err != nil {
+			t.Fatalf("failed to read pass count: %v", err)
+		}
+		if passes := strings.Count(string(data), "pass"); passes != tt.passes {
+			t.Errorf("labels=%s: ran %d passes, want %d", tt.labels, passes, tt.passes)
+		}
+	}
+
+	failing := writeScript(t, t.TempDir(), "failing", "echo '! Undefined control sequence.'\nexit 1\n")
+	_, err := CompileTeX(fakeEngine{script: failing}, filepath.Join(t.TempDir(), "resume.tex"))
+	if err == nil || !strings.Contains(err.Error(), "Undefined control sequence") {
+		t.Errorf("expected the engine's output in the error, got %v", err)
+	}
+}
+
+func TestTeXEngineArgs(t *testing.T) {
+	for _, name := range []string{EngineXelatex, EngineLualatex, EnginePdflatex} {
+		engine, err := LookupTeXEngine(name)
+		if err != nil {
+			t.Fatalf("LookupTeXEngine(%q) failed: %v", name, err)
+		}
+		args := strings.Join(engine.Args("/tmp/out/resume.tex", "/tmp/out"), " ")
+		if args != "-interaction=nonstopmode -output-directory=/tmp/out /tmp/out/resume.tex" {
+			t.Errorf("%s args = %q", name, args)
+		}
+		if engine.Passes() < 2 {
+			t.Errorf("%s should allow reruns", name)
+		}
+	}
+
+	tectonic, _ := LookupTeXEngine(EngineTectonic)
+	if args := tectonic.Args("/tmp/out/resume.tex", "/tmp/out"); !strings.Contains(strings.Join(args, " "), "--keep-logs") {
+		t.Errorf("tectonic args %q should keep the log for page counting", args)
+	}
+	if tectonic.Passes() != 1 {
+		t.Error("tectonic reruns itself and should run once")
+	}
+	if _, err := LookupTeXEngine(EngineNative); err == nil {
+		t.Error("native is not a TeX engine")
+	}
+}
+
+func TestGeneratePDFWithTeX(t *testing.T) {
+	bin := t.TempDir()
+	writeScript(t, bin, EngineXelatex, `for arg; do tex="$arg"; done
+base="${tex%.tex}"
+echo "%PDF-1.5 fake" > "$base.pdf"
+printf 'Output written on %s.pdf (1 page, 14 bytes).\n' "$base" > "$base.log"
+`)
+	t.Setenv("PATH", bin)
+
+	texFile := filepath.Join(t.TempDir(), "resume.tex")
+	pdf, err := GeneratePDF(formatTestResume(), nil, Options{Engine: EngineXelatex, TeXFile: texFile})
+	if err != nil {
+		t.Fatalf("GeneratePDF failed: %v", err)
+	}
+	if string(pdf) != "%PDF-1.5 fake\n" {
+		t.Errorf("got %q, want the engine's PDF", pdf)
+	}
+	source, err := os.ReadFile(texFile)
+	if err != nil {
+		t.Fatalf("TeXFile not written: %v", err)
+	}
+	if !strings.Contains(string(source), `\begin{document}`) {
+		t.Errorf("TeXFile does not hold the LaTeX source:\n%s", source)
+	}
+}
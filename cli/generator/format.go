@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// Output formats
+const (
+	FormatPDF      = "pdf"
+	FormatMarkdown = "md"
+	FormatText     = "txt"
+	FormatHTML     = "html"
+	FormatDOCX     = "docx"
+)
+
+// Formats lists the output formats, PDF first
+var Formats = []string{FormatPDF, FormatMarkdown, FormatText, FormatHTML, FormatDOCX}
+
+// renderer renders prepared template data in one format
+type renderer struct {
+	contentType string
+	render      func(data TemplateData, opts Options) ([]byte, error)
+}
+
+// renderers holds the formats rendered in Go, without a TeX engine
+var renderers = map[string]renderer{
+	FormatMarkdown: {"text/markdown; charset=utf-8", renderMarkdown},
+	FormatText:     {"text/plain; charset=utf-8", renderText},
+	FormatHTML:     {"text/html; charset=utf-8", renderHTML},
+	FormatDOCX:     {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", renderDOCX},
+}
+
+// ValidateFormat checks that format is empty (PDF) or one of Formats
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if format == "" || format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(Formats, ", "))
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	if r, ok := renderers[format]; ok {
+		return r.contentType
+	}
+	return "application/pdf"
+}
+
+// Render renders the selected resume content in format. PDF goes through
+// GeneratePDF. HTML styles the page after Options.Template, and the other
+// formats ignore it. Outside PDF, resume.VariantAuto uses each bullet's
+// default wording since there are no pages to overflow.
+func Render(format string, r *resume.Resume, selectedIDs map[string]bool, opts Options) ([]byte, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	rend, ok := renderers[format]
+	if !ok {
+		return GeneratePDF(r, selectedIDs, opts)
+	}
+	if opts.Variant == resume.VariantAuto {
+		opts.Variant = ""
+	}
+	return rend.render(prepareTemplateData(r, selectedIDs, opts), opts)
+}
+
+// dateRange joins formatted start and end dates with sep, or returns
+// whichever is set
+func dateRange(start, end, sep string) string {
+	switch {
+	case start == "":
+		return end
+	case end == "":
+		return start
+	}
+	return start + sep + end
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// degreeLine describes an education entry's degree, such as
+// "B.S. Computer Science, Focus: AI"
+func degreeLine(edu EducationData) string {
+	line := edu.Degree
+	if edu.Focus != "" {
+		line = joinNonEmpty(", ", line, "Focus: "+edu.Focus)
+	}
+	return line
+}
+
+// educationDetails lists an education entry's program, minor, GPA and honors
+func educationDetails(edu EducationData) []string {
+	var details []string
+	for _, d := range []struct{ label, value string }{
+		{"Program", edu.Program},
+		{"Minor", edu.Minor},
+		{"GPA", edu.GPA},
+		{"Honors", edu.Honors},
+	} {
+		if d.value != "" {
+			details = append(details, d.label+": "+d.value)
+		}
+	}
+	return details
+}
+
+// positionLocation returns where a role was based when it differs from the
+// company location shown above it
+func positionLocation(exp ExperienceData, pos PositionData) string {
+	if len(exp.Positions) == 1 {
+		return ""
+	}
+	return pos.Location
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+func formatTestResume() *resume.Resume {
+	return &resume.Resume{
+		Contact: resume.ContactInfo{
+			Name:     "Test User",
+			Email:    "test@example.com",
+			LinkedIn: "linkedin.com/in/test",
+		},
+		Summary: "Engineer — builds things",
+		Experience: []resume.ExperienceEntry{{
+			ID:       "acme",
+			Company:  "Acme",
+			Location: "Remote",
+			Positions: []resume.Position{
+				{
+					ID:        "acme-senior",
+					Title:     "Senior Engineer",
+					StartDate: mustDate("Jan 2022"),
+					EndDate:   mustDate("Present"),
+					Bullets: []resume.Bullet{{
+						ID:       "acme-1",
+						Text:     "Cut p99 latency 400ms→200ms across the fleet of services that handle every payment request we process each day",
+						Variants: map[string]string{resume.VariantShort: "Halved p99 latency"},
+					}},
+				},
+				{
+					ID:        "acme-engineer",
+					Title:     "Engineer",
+					Location:  "New York, NY",
+					StartDate: mustDate("Aug 2020"),
+					EndDate:   mustDate("Dec 2021"),
+					Bullets:   []resume.Bullet{{ID: "acme-2", Text: "Built *fast* services"}},
+				},
+			},
+		}},
+		Projects: []resume.ProjectEntry{{
+			ID:           "tool",
+			Title:        "Tool",
+			Technologies: "Go",
+			GitHub:       "github.com/test/tool",
+			Bullets:      []resume.Bullet{{ID: "tool-1", Text: "Made a tool"}},
+		}},
+		Sections: []resume.CustomSection{{
+			ID:     "awards",
+			Title:  "Awards",
+			Layout: resume.LayoutEntries,
+			Items:  []resume.CustomItem{{ID: "award-1", Title: "Best Paper", Subtitle: "ACM", Date: "2021"}},
+		}},
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	r := formatTestResume()
+	out, err := Render(FormatText, r, nil, Options{Order: &resume.SectionOrder{
+		Sections: []string{resume.SectionSummary, resume.SectionExperience, "awards", resume.SectionProjects},
+	}})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	text := string(out)
+
+	want := `TEST USER
+test@example.com | linkedin.com/in/test
+
+SUMMARY
+-------
+Engineer - builds things
+
+EXPERIENCE
+----------
+Acme, Remote
+Senior Engineer | Jan 2022 - Present
+- Cut p99 latency 400ms->200ms across the fleet of services that handle every
+  payment request we process each day
+
+Engineer | Aug 2020 - Dec 2021 | New York, NY
+- Built *fast* services
+
+AWARDS
+------
+Best Paper, ACM | 2021
+
+PROJECTS
+--------
+Tool | Go | github.com/test/tool
+- Made a tool
+`
+	if text != want {
+		t.Errorf("Render(txt) =\n%s\nwant:\n%s", text, want)
+	}
+
+	// Auto has no page count to check, so it keeps the default wording
+	out, err = Render(FormatText, r, nil, Options{Variant: resume.VariantAuto})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(string(out), "Cut p99 latency") {
+		t.Error("auto variant should render default wording")
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out, err := Render(FormatMarkdown, formatTestResume(), map[string]bool{"acme-2": true, "tool-1": true}, Options{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	md := string(out)
+	for _, want := range []string{
+		"# Test User\n",
+		"[test@example.com](mailto:test@example.com)",
+		"[linkedin.com/in/test](https://linkedin.com/in/test)",
+		"## Experience\n\n### Acme, New York, NY\n\n**Engineer** | Aug 2020 – Dec 2021\n\n- Built \\*fast\\* services\n",
+		"### [Tool](https://github.com/test/tool)\n\n*Go*\n",
+		"**Best Paper**, ACM | 2021",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Senior Engineer") {
+		t.Error("unselected position should not be rendered")
+	}
+}
+
+func TestMarkdownLink(t *testing.T) {
+	got := markdownLink("Docs (v2)", "example.com/docs (v2)/<draft>")
+	want := `[Docs (v2)](https://example.com/docs%20%28v2%29/%3Cdraft%3E)`
+	if got != want {
+		t.Errorf("markdownLink = %q, want %q", got, want)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := wrap("aaa bbb ccc dddddddddddd e", 7, "  ")
+	want := []string{"aaa bbb", "  ccc", "  dddddddddddd", "  e"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrap = %q, want %q", got, want)
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{"", FormatPDF, FormatMarkdown, FormatText} {
+		if err := ValidateFormat(format); err != nil {
+			t.Errorf("ValidateFormat(%q) = %v", format, err)
+		}
+	}
+	if err := ValidateFormat("doc"); err == nil {
+		t.Error("ValidateFormat should reject unknown formats")
+	}
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+//go:embed html
+var htmlFiles embed.FS
+
+// htmlTemplate renders a standalone HTML page from TemplateData
+var htmlTemplate = template.Must(template.New("resume.html").Funcs(template.FuncMap{
+	"href":             href,
+	"dates":            func(start, end string) string { return dateRange(start, end, " – ") },
+	"degree":           degreeLine,
+	"details":          educationDetails,
+	"positionLocation": positionLocation,
+	"join":             strings.Join,
+}).ParseFS(htmlFiles, "html/resume.html"))
+
+// htmlPage is the data passed to htmlTemplate
+type htmlPage struct {
+	TemplateData
+	// Theme names the LaTeX template the page is styled after
+	Theme string
+	Style template.CSS
+	Print template.CSS
+}
+
+// href turns a link written without its scheme, such as
+// "github.com/user", into an absolute URL
+func href(url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	return "https://" + url
+}
+
+// renderHTML renders a standalone web page with the stylesheets inlined.
+// The page is themed after the LaTeX template named by opts.Template; a
+// template without a matching theme in html/themes gets the base theme.
+func renderHTML(data TemplateData, opts Options) ([]byte, error) {
+	t, err := opts.registry().Get(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	style, err := htmlFiles.ReadFile("html/resume.css")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stylesheet: %w", err)
+	}
+	if theme, err := htmlFiles.ReadFile("html/themes/" + t.Name + ".css"); err == nil {
+		style = append(append(style, '\n'), theme...)
+	}
+	printStyle, err := htmlFiles.ReadFile("html/print.css")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read print stylesheet: %w", err)
+	}
+
+	page := htmlPage{
+		TemplateData: data,
+		Theme:        t.Name,
+		Style:        template.CSS(style),
+		Print:        template.CSS(printStyle),
+	}
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, page); err != nil {
+		return nil, fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
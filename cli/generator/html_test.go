@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	r := formatTestResume()
+	r.Contact.Name = "Test <User>"
+	r.Projects[0].GitHub = "javascript:alert(1)"
+
+	out, err := Render(FormatHTML, r, map[string]bool{"acme-2": true, "tool-1": true}, Options{Template: "classic"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	page := string(out)
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h1>Test &lt;User&gt;</h1>",
+		`<a href="mailto:test@example.com">test@example.com</a>`,
+		`<a href="https://linkedin.com/in/test">linkedin.com/in/test</a>`,
+		`<body class="theme-classic">`,
+		"TeX Gyre Termes",
+		`<style media="print">`,
+		"<li>Built *fast* services</li>",
+		"Aug 2020 – Dec 2021",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(page, "Senior Engineer") {
+		t.Error("unselected position should not be rendered")
+	}
+	if strings.Contains(page, `href="javascript:`) {
+		t.Error("unsafe link was not sanitized")
+	}
+
+	// Templates without an HTML theme fall back to the base theme
+	out, err = Render(FormatHTML, r, nil, Options{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(string(out), `<body class="theme-modern">`) || strings.Contains(string(out), "TeX Gyre Termes") {
+		t.Error("default template should use the base theme")
+	}
+
+	if _, err := Render(FormatHTML, r, nil, Options{Template: "nonexistent"}); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestHref(t *testing.T) {
+	tests := map[string]string{
+		"github.com/test":         "https://github.com/test",
+		"http://example.com":      "http://example.com",
+		"https://linkedin.com/in": "https://linkedin.com/in",
+	}
+	for in, want := range tests {
+		if got := href(in); got != want {
+			t.Errorf("href(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
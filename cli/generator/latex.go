@@ -2,39 +2,121 @@ package generator
 
 import (
 	"bytes"
-	_ "embed"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
-	"text/template"
+	"time"
 
 	"github.com/evanqhuang/resume-cli/resume"
 )
 
-//go:embed templates/modern.tex
-var modernTemplate string
-
 // TemplateData holds data for LaTeX template
 type TemplateData struct {
+	// Sections lists the sections to render, in order. Hidden and empty
+	// sections are left out, so templates should range over it.
+	Sections      []SectionData
 	Contact       resume.ContactInfo
 	Summary       string
-	Education     resume.EducationEntry
-	Skills        resume.Skills
+	Education     []EducationData
+	Skills        []SkillCategoryData
 	Experience    []ExperienceData
 	Projects      []ProjectData
 	Leadership    []string
 	IncludeSkills bool
 }
 
+// SectionData identifies one rendered section
+type SectionData struct {
+	Name  string
+	Title string
+	// Custom holds the content of a user-defined section; nil for built-in sections
+	Custom *CustomSectionData
+}
+
+// sectionTitles are the default headings for each section
+var sectionTitles = map[string]string{
+	resume.SectionSummary:    "Summary",
+	resume.SectionEducation:  "Education",
+	resume.SectionSkills:     "Technical Skills",
+	resume.SectionExperience: "Experience",
+	resume.SectionProjects:   "Projects",
+	resume.SectionLeadership: "Leadership",
+}
+
+// EducationData holds education data for template
+type EducationData struct {
+	Institution string
+	Location    string
+	Degree      string
+	Focus       string
+	Program     string
+	Minor       string
+	GPA         string
+	Honors      string
+	// StartDate and EndDate are formatted with Options.DateFormat
+	StartDate  string
+	EndDate    string
+	Start      resume.Date
+	End        resume.Date
+	Coursework []string
+}
+
+// SkillCategoryData holds one skill category for template
+type SkillCategoryData struct {
+	Name   string
+	Label  string
+	Skills []string
+}
+
+// CustomSectionData holds a user-defined section for template
+type CustomSectionData struct {
+	ID     string
+	Layout string
+	Items  []CustomItemData
+}
+
+// CustomItemData holds one custom section item for template
+type CustomItemData struct {
+	Text     string
+	Title    string
+	Subtitle string
+	Date     string
+	Key      string
+	Value    string
+}
+
 // ExperienceData holds experience data for template
 type ExperienceData struct {
-	Title     string
-	Company   string
+	Title    string
+	Company  string
+	Location string
+	// StartDate and EndDate are formatted with Options.DateFormat
+	StartDate string
+	EndDate   string
+	Start     resume.Date
+	End       resume.Date
+	// Duration is the tenure, such as "2 yrs 3 mos"
+	Duration string
+	// Bullets holds the bullets of every position, in order
+	Bullets []string
+	// Positions lists the roles held at the company; templates render them
+	// grouped under one company heading when there is more than one
+	Positions []PositionData
+}
+
+// PositionData holds one role within an experience entry for template
+type PositionData struct {
+	Title string
+	// Location is set only when the role was based away from the entry's location
 	Location  string
 	StartDate string
 	EndDate   string
+	Start     resume.Date
+	End       resume.Date
+	Duration  string
 	Bullets   []string
 }
 
@@ -46,86 +128,233 @@ type ProjectData struct {
 	Bullets      []string
 }
 
+// Options controls how a resume is rendered
+type Options struct {
+	// Template is the name of the LaTeX template; empty selects DefaultTemplate
+	Template string
+	// Templates resolves Template; nil uses the built-in templates only
+	Templates *Registry
+	// Order sets the entry order within each section; nil keeps YAML order
+	Order *resume.SectionOrder
+	// Sections overrides the section sequence from Order; omitted sections are hidden
+	Sections []string
+	// DateFormat is a Go time layout for dates, such as "Jan 2006"; empty keeps dates as written
+	DateFormat string
+	// Variant selects the bullet wording; empty uses each bullet's Text.
+	// resume.VariantAuto is resolved by GeneratePDF.
+	Variant string
+	// BulletVariants chooses a variant for individual bullets by ID,
+	// overriding Variant
+	BulletVariants map[string]string
+	// Engine is the PDF engine, one of Engines; empty is resolved by
+	// ResolveEngine
+	Engine string
+	// TeXFile, if set, receives the LaTeX source of a PDF compiled with TeX
+	TeXFile string
+}
+
+var builtinRegistry = NewRegistry()
+
+// registry returns the registry that resolves Template
+func (o Options) registry() *Registry {
+	if o.Templates == nil {
+		return builtinRegistry
+	}
+	return o.Templates
+}
+
 // GenerateLatex generates LaTeX source from resume data
-func GenerateLatex(r *resume.Resume, selectedIDs map[string]bool) (string, error) {
-	data := prepareTemplateData(r, selectedIDs)
+func GenerateLatex(r *resume.Resume, selectedIDs map[string]bool, opts Options) (string, error) {
+	data := prepareTemplateData(r, selectedIDs, opts)
 
-	tmpl, err := template.New("resume").Funcs(template.FuncMap{
-		"escape": escapeLaTeX,
-	}).Parse(modernTemplate)
+	t, err := opts.registry().Get(opts.Template)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", err
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := t.tmpl.Execute(&buf, data); err != nil {
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	return buf.String(), nil
 }
 
-func prepareTemplateData(r *resume.Resume, selectedIDs map[string]bool) TemplateData {
-	includeAll := len(selectedIDs) == 0
+func prepareTemplateData(r *resume.Resume, selectedIDs map[string]bool, opts Options) TemplateData {
+	order := opts.Order
+	sel := r.Select(selectedIDs, order)
 
 	data := TemplateData{
-		Contact:       r.Contact,
-		Summary:       r.Summary,
-		Education:     r.Education,
-		Skills:        r.Skills,
-		IncludeSkills: true, // Always include skills section
+		Contact: r.Contact,
+		Summary: r.Summary,
 	}
 
-	// Process experience entries
-	for _, exp := range r.Experience {
-		var bullets []string
-		for _, bullet := range exp.Bullets {
-			if includeAll || selectedIDs[bullet.ID] {
-				bullets = append(bullets, bullet.Text)
-			}
+	for _, edu := range sel.Education {
+		var coursework []string
+		for _, course := range edu.Coursework {
+			coursework = append(coursework, opts.bulletText(course))
 		}
-		// Only include experience entries that have at least one bullet
-		if len(bullets) > 0 {
-			data.Experience = append(data.Experience, ExperienceData{
-				Title:     exp.Title,
-				Company:   exp.Company,
-				Location:  exp.Location,
-				StartDate: exp.StartDate,
-				EndDate:   exp.EndDate,
+		data.Education = append(data.Education, EducationData{
+			Institution: edu.Institution,
+			Location:    edu.Location,
+			Degree:      edu.Degree,
+			Focus:       edu.Focus,
+			Program:     edu.Program,
+			Minor:       edu.Minor,
+			GPA:         edu.GPA,
+			Honors:      edu.Honors,
+			StartDate:   edu.StartDate.Format(opts.DateFormat),
+			EndDate:     edu.EndDate.Format(opts.DateFormat),
+			Start:       edu.StartDate,
+			End:         edu.EndDate,
+			Coursework:  coursework,
+		})
+	}
+
+	for _, category := range sel.Skills {
+		var skills []string
+		for _, skill := range category.Items {
+			skills = append(skills, skill.Name)
+		}
+		data.Skills = append(data.Skills, SkillCategoryData{
+			Name:   category.Name,
+			Label:  category.DisplayLabel(),
+			Skills: skills,
+		})
+	}
+
+	for _, exp := range sel.Experience {
+		roles := exp.Roles()
+		entry := ExperienceData{Company: exp.Company, Location: exp.Location}
+		for _, role := range roles {
+			var bullets []string
+			for _, bullet := range role.Bullets {
+				bullets = append(bullets, opts.bulletText(bullet))
+			}
+			entry.Positions = append(entry.Positions, PositionData{
+				Title:     role.Title,
+				Location:  role.Location,
+				StartDate: role.StartDate.Format(opts.DateFormat),
+				EndDate:   role.EndDate.Format(opts.DateFormat),
+				Start:     role.StartDate,
+				End:       role.EndDate,
+				Duration:  duration(role.StartDate, role.EndDate),
 				Bullets:   bullets,
 			})
+			entry.Bullets = append(entry.Bullets, bullets...)
+		}
+
+		// Entry-level fields summarize the kept roles, so templates that
+		// ignore Positions still render a sensible single heading
+		start, end := resume.PositionSpan(roles, now())
+		entry.Title = entry.Positions[0].Title
+		entry.StartDate = start.Format(opts.DateFormat)
+		entry.EndDate = end.Format(opts.DateFormat)
+		entry.Start, entry.End = start, end
+		entry.Duration = duration(start, end)
+		if len(roles) == 1 && entry.Positions[0].Location != "" {
+			entry.Location = entry.Positions[0].Location
 		}
+		data.Experience = append(data.Experience, entry)
 	}
 
-	// Process project entries
-	for _, proj := range r.Projects {
+	for _, proj := range sel.Projects {
 		var bullets []string
 		for _, bullet := range proj.Bullets {
-			if includeAll || selectedIDs[bullet.ID] {
-				bullets = append(bullets, bullet.Text)
-			}
+			bullets = append(bullets, opts.bulletText(bullet))
 		}
-		// Only include projects that have at least one bullet
-		if len(bullets) > 0 {
-			data.Projects = append(data.Projects, ProjectData{
-				Title:        proj.Title,
-				Technologies: proj.Technologies,
-				GitHub:       proj.GitHub,
-				Bullets:      bullets,
+		data.Projects = append(data.Projects, ProjectData{
+			Title:        proj.Title,
+			Technologies: proj.Technologies,
+			GitHub:       proj.GitHub,
+			Bullets:      bullets,
+		})
+	}
+
+	for _, lead := range sel.Leadership {
+		data.Leadership = append(data.Leadership, lead.Text)
+	}
+
+	custom := make(map[string]*CustomSectionData, len(sel.Sections))
+	for _, section := range sel.Sections {
+		sectionData := &CustomSectionData{ID: section.ID, Layout: section.LayoutName()}
+		for _, item := range section.Items {
+			sectionData.Items = append(sectionData.Items, CustomItemData{
+				Text:     item.Text,
+				Title:    item.Title,
+				Subtitle: item.Subtitle,
+				Date:     item.Date,
+				Key:      item.Key,
+				Value:    item.Value,
 			})
 		}
+		custom[section.ID] = sectionData
 	}
 
-	// Process leadership entries
-	for _, lead := range r.Leadership {
-		if includeAll || selectedIDs[lead.ID] {
-			data.Leadership = append(data.Leadership, lead.Text)
+	// Build the section sequence, skipping sections with nothing to show
+	sequence := opts.Sections
+	if len(sequence) == 0 {
+		sequence = order.SectionSequence(r)
+	}
+	for _, name := range sequence {
+		if sectionData, ok := custom[name]; ok {
+			title := sel.CustomSection(name).Title
+			data.Sections = append(data.Sections, SectionData{Name: name, Title: title, Custom: sectionData})
+			continue
+		}
+		if !data.hasContent(name) {
+			continue
+		}
+		data.Sections = append(data.Sections, SectionData{Name: name, Title: sectionTitles[name]})
+		if name == resume.SectionSkills {
+			data.IncludeSkills = true
 		}
 	}
 
 	return data
 }
 
+// hasContent reports whether a section has anything to render
+func (d *TemplateData) hasContent(name string) bool {
+	switch name {
+	case resume.SectionSummary:
+		return d.Summary != ""
+	case resume.SectionEducation:
+		return len(d.Education) > 0
+	case resume.SectionSkills:
+		return len(d.Skills) > 0
+	case resume.SectionExperience:
+		return len(d.Experience) > 0
+	case resume.SectionProjects:
+		return len(d.Projects) > 0
+	case resume.SectionLeadership:
+		return len(d.Leadership) > 0
+	}
+	return false
+}
+
+// now is the clock used to resolve "Present" dates
+var now = time.Now
+
+// duration formats the tenure between two dates, such as "2 yrs 3 mos"
+func duration(start, end resume.Date) string {
+	return resume.FormatMonths(resume.MonthsBetween(start, end, now()))
+}
+
+// formatDate renders a date with a Go time layout for use in templates
+func formatDate(d resume.Date, layout string) string {
+	return d.Format(layout)
+}
+
+// bulletText returns a bullet's wording for the chosen variant
+func (o Options) bulletText(b resume.Bullet) string {
+	variant, ok := o.BulletVariants[b.ID]
+	if !ok {
+		variant = o.Variant
+	}
+	return b.TextFor(variant)
+}
+
 // escapeLaTeX escapes special LaTeX characters
 func escapeLaTeX(s string) string {
 	// Must escape backslash first to avoid double-escaping
@@ -154,77 +383,96 @@ func escapeLaTeX(s string) string {
 	return result
 }
 
-// Common xelatex installation paths to check
-var xelatexPaths = []string{
-	"/Library/TeX/texbin/xelatex",                          // MacTeX on macOS
-	"/usr/local/texlive/2025/bin/universal-darwin/xelatex", // TeX Live 2025 macOS
-	"/usr/local/texlive/2024/bin/universal-darwin/xelatex", // TeX Live 2024 macOS
-	"/usr/local/texlive/2023/bin/universal-darwin/xelatex", // TeX Live 2023 macOS
-	"/usr/local/texlive/2025/bin/x86_64-linux/xelatex",    // TeX Live 2025 Linux
-	"/usr/local/texlive/2024/bin/x86_64-linux/xelatex",    // TeX Live 2024 Linux
-	"/usr/local/texlive/2023/bin/x86_64-linux/xelatex",    // TeX Live 2023 Linux
-	"/usr/bin/xelatex",                                     // System installation
-}
-
-// FindXelatex locates the xelatex executable
-func FindXelatex() (string, error) {
-	// First try PATH
-	if path, err := exec.LookPath("xelatex"); err == nil {
-		return path, nil
+// GeneratePDF generates a PDF from resume data and returns the bytes.
+// With resume.VariantAuto, bullets use their default wording unless the
+// result runs past one page, in which case it is rebuilt with short variants.
+func GeneratePDF(r *resume.Resume, selectedIDs map[string]bool, opts Options) ([]byte, error) {
+	auto := opts.Variant == resume.VariantAuto
+	if auto {
+		opts.Variant = ""
 	}
 
-	// Check common installation paths
-	for _, path := range xelatexPaths {
-		if _, err := os.Stat(path); err == nil {
-			return path, nil
+	pdfBytes, pages, err := generatePDF(r, selectedIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+	if auto && pages > 1 {
+		opts.Variant = resume.VariantShort
+		pdfBytes, _, err = generatePDF(r, selectedIDs, opts)
+		if err != nil {
+			return nil, err
 		}
 	}
-
-	return "", fmt.Errorf("xelatex not found. Install LaTeX (e.g., 'brew install --cask mactex' on macOS)")
+	return pdfBytes, nil
 }
 
-// GeneratePDF generates a PDF from resume data and returns the bytes
-func GeneratePDF(r *resume.Resume, selectedIDs map[string]bool) ([]byte, error) {
+// generatePDF renders resume data once, returning the PDF and its page count
+func generatePDF(r *resume.Resume, selectedIDs map[string]bool, opts Options) ([]byte, int, error) {
+	t, err := opts.registry().Get(opts.Template)
+	if err != nil {
+		return nil, 0, err
+	}
+	engine, err := ResolveEngine(opts.Engine, t)
+	if err != nil {
+		return nil, 0, err
+	}
+	if engine == EngineNative {
+		return renderNativePDF(prepareTemplateData(r, selectedIDs, opts))
+	}
+	texEngine, err := LookupTeXEngine(engine)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Generate LaTeX content
-	latexContent, err := GenerateLatex(r, selectedIDs)
+	latexContent, err := GenerateLatex(r, selectedIDs, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate LaTeX: %w", err)
+		return nil, 0, fmt.Errorf("failed to generate LaTeX: %w", err)
 	}
 
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "resume-pdf-*")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, 0, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 	defer os.RemoveAll(tmpDir)
 
 	// Write LaTeX to temp file
 	texFile := filepath.Join(tmpDir, "resume.tex")
 	if err := os.WriteFile(texFile, []byte(latexContent), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write LaTeX file: %w", err)
+		return nil, 0, fmt.Errorf("failed to write LaTeX file: %w", err)
 	}
-
-	// Find xelatex
-	xelatexPath, err := FindXelatex()
-	if err != nil {
-		return nil, err
+	if opts.TeXFile != "" {
+		if err := os.WriteFile(opts.TeXFile, []byte(latexContent), 0644); err != nil {
+			return nil, 0, fmt.Errorf("failed to write LaTeX file: %w", err)
+		}
 	}
 
-	// Compile to PDF (run twice for proper formatting)
-	for i := 0; i < 2; i++ {
-		cmd := exec.Command(xelatexPath, "-interaction=nonstopmode", "-output-directory="+tmpDir, texFile)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return nil, fmt.Errorf("xelatex failed: %w\nOutput: %s", err, string(output))
-		}
+	pages, err := CompileTeX(texEngine, texFile)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Read the generated PDF
 	pdfFile := filepath.Join(tmpDir, "resume.pdf")
 	pdfBytes, err := os.ReadFile(pdfFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read PDF: %w", err)
+		return nil, 0, fmt.Errorf("failed to read PDF: %w", err)
 	}
 
-	return pdfBytes, nil
+	return pdfBytes, pages, nil
+}
+
+// pageCountPattern matches the TeX summary line, e.g.
+// "Output written on resume.pdf (2 pages)." Long paths may wrap the line.
+var pageCountPattern = regexp.MustCompile(`Output written on [^(]*\((\d+) pages?`)
+
+// PageCount extracts the page count from TeX output or a log file, or 0 if absent
+func PageCount(output string) int {
+	match := pageCountPattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0
+	}
+	pages, _ := strconv.Atoi(match[1])
+	return pages
 }
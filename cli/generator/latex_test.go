@@ -3,6 +3,7 @@ package generator
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evanqhuang/resume-cli/resume"
 )
@@ -40,22 +41,22 @@ func TestGenerateLatex(t *testing.T) {
 			LinkedIn: "linkedin.com/in/test",
 			GitHub:   "github.com/test",
 		},
-		Education: resume.EducationEntry{
+		Education: resume.Education{{
 			Institution: "Test University",
 			Location:    "Test City",
 			Degree:      "B.S. Computer Science",
 			GPA:         "3.5/4.0",
-		},
+		}},
 		Skills: resume.Skills{
-			Languages: []resume.SkillItem{
+			{Name: "languages", Items: []resume.SkillItem{
 				{Name: "Go", Tags: []string{"go"}},
-			},
-			Frameworks: []resume.SkillItem{
+			}},
+			{Name: "frameworks", Items: []resume.SkillItem{
 				{Name: "Docker", Tags: []string{"docker"}},
-			},
-			Cloud: []resume.SkillItem{
+			}},
+			{Name: "cloud", Items: []resume.SkillItem{
 				{Name: "AWS", Tags: []string{"aws"}},
-			},
+			}},
 		},
 		Experience: []resume.ExperienceEntry{
 			{
@@ -63,8 +64,8 @@ func TestGenerateLatex(t *testing.T) {
 				Title:     "Software Engineer",
 				Company:   "Test Company",
 				Location:  "Test City",
-				StartDate: "Jan 2020",
-				EndDate:   "Present",
+				StartDate: mustDate("Jan 2020"),
+				EndDate:   mustDate("Present"),
 				Bullets: []resume.Bullet{
 					{ID: "bullet-1", Text: "Did something cool with Go & Docker"},
 				},
@@ -72,7 +73,7 @@ func TestGenerateLatex(t *testing.T) {
 		},
 	}
 
-	latex, err := GenerateLatex(r, nil)
+	latex, err := GenerateLatex(r, nil, Options{})
 	if err != nil {
 		t.Fatalf("GenerateLatex failed: %v", err)
 	}
@@ -103,11 +104,11 @@ func TestGenerateLatexWithFiltering(t *testing.T) {
 		Contact: resume.ContactInfo{
 			Name: "Test User",
 		},
-		Education: resume.EducationEntry{
+		Education: resume.Education{{
 			Institution: "Test University",
-		},
+		}},
 		Skills: resume.Skills{
-			Languages: []resume.SkillItem{{Name: "Go"}},
+			{Name: "languages", Items: []resume.SkillItem{{Name: "Go"}}},
 		},
 		Experience: []resume.ExperienceEntry{
 			{
@@ -124,7 +125,7 @@ func TestGenerateLatexWithFiltering(t *testing.T) {
 
 	// Filter to include only bullet-1
 	selectedIDs := map[string]bool{"bullet-1": true}
-	latex, err := GenerateLatex(r, selectedIDs)
+	latex, err := GenerateLatex(r, selectedIDs, Options{})
 	if err != nil {
 		t.Fatalf("GenerateLatex failed: %v", err)
 	}
@@ -139,3 +140,466 @@ func TestGenerateLatexWithFiltering(t *testing.T) {
 		t.Error("LaTeX output should not include unfiltered bullet")
 	}
 }
+
+func TestGenerateLatexAllBuiltinTemplates(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User", Email: "test@example.com"},
+		Summary: "Engineer",
+		Skills: resume.Skills{
+			{Name: "languages", Items: []resume.SkillItem{{Name: "Go"}}},
+		},
+		Experience: []resume.ExperienceEntry{
+			{ID: "exp-1", Company: "Company A", Bullets: []resume.Bullet{{ID: "b1", Text: "Bullet 1"}}},
+		},
+	}
+
+	for _, tmpl := range NewRegistry().List() {
+		latex, err := GenerateLatex(r, nil, Options{Template: tmpl.Name})
+		if err != nil {
+			t.Fatalf("GenerateLatex with template %q failed: %v", tmpl.Name, err)
+		}
+		if !strings.Contains(latex, "Company A") || !strings.Contains(latex, "Bullet 1") {
+			t.Errorf("template %q did not render experience", tmpl.Name)
+		}
+	}
+}
+
+func TestGenerateLatexWithOrder(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Projects: []resume.ProjectEntry{
+			{ID: "proj-a", Title: "Project Alpha", Bullets: []resume.Bullet{{ID: "a1", Text: "Alpha bullet"}}},
+			{ID: "proj-b", Title: "Project Beta", Bullets: []resume.Bullet{{ID: "b1", Text: "Beta bullet"}}},
+		},
+	}
+	order := &resume.SectionOrder{Projects: []string{"proj-b", "proj-a"}}
+
+	latex, err := GenerateLatex(r, nil, Options{Order: order})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+
+	if strings.Index(latex, "Project Beta") > strings.Index(latex, "Project Alpha") {
+		t.Error("projects not rendered in custom order")
+	}
+
+	// The source resume must keep its YAML order
+	if r.Projects[0].ID != "proj-a" {
+		t.Error("GenerateLatex mutated the resume's project order")
+	}
+}
+
+func TestGenerateLatexWithBulletOrder(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Experience: []resume.ExperienceEntry{
+			{
+				ID:      "exp-1",
+				Company: "Company A",
+				Bullets: []resume.Bullet{
+					{ID: "bullet-1", Text: "First in YAML"},
+					{ID: "bullet-2", Text: "Second in YAML"},
+				},
+			},
+		},
+	}
+	order := &resume.SectionOrder{Bullets: map[string][]string{"exp-1": {"bullet-2", "bullet-1"}}}
+
+	latex, err := GenerateLatex(r, nil, Options{Order: order})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+
+	if strings.Index(latex, "Second in YAML") > strings.Index(latex, "First in YAML") {
+		t.Error("bullets not rendered in custom order")
+	}
+	if r.Experience[0].Bullets[0].ID != "bullet-1" {
+		t.Error("GenerateLatex mutated the resume's bullet order")
+	}
+}
+
+func TestGenerateLatexSectionSequence(t *testing.T) {
+	r := &resume.Resume{
+		Contact:   resume.ContactInfo{Name: "Test User"},
+		Education: resume.Education{{Institution: "Test University"}},
+		Experience: []resume.ExperienceEntry{
+			{ID: "exp-1", Company: "Company A", Bullets: []resume.Bullet{{ID: "e1", Text: "Experience bullet"}}},
+		},
+		Projects: []resume.ProjectEntry{
+			{ID: "proj-1", Title: "Project Alpha", Bullets: []resume.Bullet{{ID: "p1", Text: "Project bullet"}}},
+		},
+	}
+
+	// Projects before experience, education hidden
+	order := &resume.SectionOrder{Sections: []string{resume.SectionProjects, resume.SectionExperience}}
+	latex, err := GenerateLatex(r, nil, Options{Order: order})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if strings.Index(latex, "Project Alpha") > strings.Index(latex, "Company A") {
+		t.Error("projects should render before experience")
+	}
+	if strings.Contains(latex, "Test University") {
+		t.Error("hidden education section should not render")
+	}
+
+	// An explicit sequence overrides order.yaml
+	latex, err = GenerateLatex(r, nil, Options{Order: order, Sections: []string{resume.SectionEducation}})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if !strings.Contains(latex, "Test University") || strings.Contains(latex, "Company A") {
+		t.Error("Options.Sections should override the order's section sequence")
+	}
+}
+
+func TestPrepareTemplateDataSkipsEmptySections(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Summary: "Summary text",
+	}
+
+	data := prepareTemplateData(r, nil, Options{})
+	if len(data.Sections) != 1 || data.Sections[0].Name != resume.SectionSummary {
+		t.Errorf("Sections = %+v, want only summary", data.Sections)
+	}
+	if data.IncludeSkills {
+		t.Error("IncludeSkills should be false without skills")
+	}
+}
+
+func TestGenerateLatexSkillSelection(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Skills: resume.Skills{
+			{Name: "languages", Items: []resume.SkillItem{{Name: "Go"}, {Name: "Python"}}},
+			{Name: "frameworks", Items: []resume.SkillItem{{ID: "k8s", Name: "Kubernetes"}}},
+		},
+		Experience: []resume.ExperienceEntry{
+			{ID: "exp-1", Company: "Company A", Bullets: []resume.Bullet{{ID: "bullet-1", Text: "Bullet 1"}}},
+		},
+	}
+
+	latex, err := GenerateLatex(r, map[string]bool{"bullet-1": true, "skill-go": true, "k8s": true}, Options{})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if !strings.Contains(latex, "Go") || !strings.Contains(latex, "Kubernetes") {
+		t.Error("selected skills missing from output")
+	}
+	if strings.Contains(latex, "Python") {
+		t.Error("unselected skill should not be rendered")
+	}
+
+	// Selecting only bullets leaves skills unfiltered
+	latex, err = GenerateLatex(r, map[string]bool{"bullet-1": true}, Options{})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if !strings.Contains(latex, "Python") {
+		t.Error("skills should not be filtered when none are selected")
+	}
+}
+
+func TestGenerateLatexMultipleEducation(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Education: resume.Education{
+			{
+				ID:          "edu-ms",
+				Institution: "Graduate School",
+				Degree:      "M.S. Computer Science",
+				StartDate:   mustDate("Aug 2023"),
+				EndDate:     mustDate("May 2025"),
+				Coursework: []resume.Bullet{
+					{ID: "course-ds", Text: "Distributed Systems"},
+					{ID: "course-ml", Text: "Machine Learning"},
+				},
+			},
+			{Institution: "Test University", Degree: "B.S. Computer Science", GPA: "3.5/4.0"},
+		},
+	}
+
+	for _, name := range []string{"modern", "classic", "compact"} {
+		latex, err := GenerateLatex(r, nil, Options{Template: name})
+		if err != nil {
+			t.Fatalf("%s: GenerateLatex failed: %v", name, err)
+		}
+		for _, want := range []string{"Graduate School", "Test University", "Aug 2023 -- May 2025", "Machine Learning"} {
+			if !strings.Contains(latex, want) {
+				t.Errorf("%s: output missing %q", name, want)
+			}
+		}
+	}
+
+	// Selecting one course keeps its entry but drops the other entry and course
+	data := prepareTemplateData(r, map[string]bool{"course-ds": true}, Options{})
+	if len(data.Education) != 1 || data.Education[0].Institution != "Graduate School" {
+		t.Fatalf("Education = %+v, want only Graduate School", data.Education)
+	}
+	if got := data.Education[0].Coursework; len(got) != 1 || got[0] != "Distributed Systems" {
+		t.Errorf("Coursework = %v, want [Distributed Systems]", got)
+	}
+
+	// Education order and coursework order come from order.yaml
+	order := &resume.SectionOrder{
+		Education: []string{"edu-test-university", "edu-ms"},
+		Bullets:   map[string][]string{"edu-ms": {"course-ml", "course-ds"}},
+	}
+	data = prepareTemplateData(r, nil, Options{Order: order})
+	if data.Education[0].Institution != "Test University" {
+		t.Errorf("first education = %q, want Test University", data.Education[0].Institution)
+	}
+	if got := data.Education[1].Coursework; got[0] != "Machine Learning" {
+		t.Errorf("Coursework = %v, want Machine Learning first", got)
+	}
+}
+
+func TestGenerateLatexCustomSections(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Experience: []resume.ExperienceEntry{
+			{ID: "exp-1", Company: "Company A", Bullets: []resume.Bullet{{ID: "bullet-1", Text: "Bullet 1"}}},
+		},
+		Sections: []resume.CustomSection{
+			{ID: "certifications", Title: "Certifications & Licenses", Layout: resume.LayoutEntries, Items: []resume.CustomItem{
+				{ID: "cert-aws", Title: "AWS Solutions Architect", Subtitle: "Amazon", Date: "2024"},
+				{ID: "cert-cka", Title: "Certified Kubernetes Administrator", Date: "2023"},
+			}},
+			{ID: "languages-spoken", Title: "Languages", Layout: resume.LayoutTable, Items: []resume.CustomItem{
+				{ID: "lang-es", Key: "Spanish", Value: "Professional"},
+			}},
+			{ID: "awards", Title: "Awards", Items: []resume.CustomItem{
+				{ID: "award-1", Text: "First place, HackOHI/O"},
+			}},
+		},
+	}
+
+	for _, name := range []string{"modern", "classic", "compact"} {
+		latex, err := GenerateLatex(r, nil, Options{Template: name})
+		if err != nil {
+			t.Fatalf("%s: GenerateLatex failed: %v", name, err)
+		}
+		for _, want := range []string{`Certifications \& Licenses`, "AWS Solutions Architect", "Spanish", "First place, HackOHI/O"} {
+			if !strings.Contains(latex, want) {
+				t.Errorf("%s: output missing %q", name, want)
+			}
+		}
+	}
+
+	// Custom sections follow the built-in sections by default
+	data := prepareTemplateData(r, nil, Options{})
+	var names []string
+	for _, section := range data.Sections {
+		names = append(names, section.Name)
+	}
+	want := []string{resume.SectionExperience, "certifications", "languages-spoken", "awards"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("Sections = %v, want %v", names, want)
+	}
+
+	// Selecting one certification filters that section only; item order comes from order.yaml
+	order := &resume.SectionOrder{
+		Sections: []string{"certifications", "awards", resume.SectionExperience},
+		Bullets:  map[string][]string{"certifications": {"cert-cka", "cert-aws"}},
+	}
+	data = prepareTemplateData(r, map[string]bool{"bullet-1": true, "cert-cka": true}, Options{Order: order})
+	if len(data.Sections) != 3 || data.Sections[0].Name != "certifications" {
+		t.Fatalf("Sections = %+v, want certifications first", data.Sections)
+	}
+	if items := data.Sections[0].Custom.Items; len(items) != 1 || items[0].Title != "Certified Kubernetes Administrator" {
+		t.Errorf("certification items = %+v, want only the CKA", items)
+	}
+	if items := data.Sections[1].Custom.Items; len(items) != 1 {
+		t.Errorf("awards should be unfiltered, got %+v", items)
+	}
+
+	data = prepareTemplateData(r, nil, Options{Order: &resume.SectionOrder{
+		Bullets: map[string][]string{"certifications": {"cert-cka", "cert-aws"}},
+	}})
+	if data.Sections[1].Custom.Items[0].Title != "Certified Kubernetes Administrator" {
+		t.Errorf("item order not applied: %+v", data.Sections[1].Custom.Items)
+	}
+}
+
+func TestGenerateLatexDates(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC) }
+
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Experience: []resume.ExperienceEntry{
+			{
+				ID:        "exp-1",
+				Company:   "Company A",
+				StartDate: mustDate("August 2023"),
+				EndDate:   mustDate("Present"),
+				Bullets:   []resume.Bullet{{ID: "bullet-1", Text: "Bullet 1"}},
+			},
+			{
+				ID:        "exp-2",
+				Company:   "Company B",
+				StartDate: mustDate("2021-06"),
+				EndDate:   mustDate("2022"),
+				Bullets:   []resume.Bullet{{ID: "bullet-2", Text: "Bullet 2"}},
+			},
+		},
+	}
+
+	// Dates render as written by default
+	data := prepareTemplateData(r, nil, Options{})
+	if data.Experience[0].StartDate != "August 2023" || data.Experience[0].EndDate != "Present" {
+		t.Errorf("dates = %q - %q, want as written", data.Experience[0].StartDate, data.Experience[0].EndDate)
+	}
+	if data.Experience[0].Duration != "2 yrs 8 mos" {
+		t.Errorf("Duration = %q, want %q", data.Experience[0].Duration, "2 yrs 8 mos")
+	}
+	if data.Experience[1].Duration != "1 yr 7 mos" {
+		t.Errorf("Duration = %q, want %q", data.Experience[1].Duration, "1 yr 7 mos")
+	}
+
+	data = prepareTemplateData(r, nil, Options{DateFormat: "01/2006"})
+	if data.Experience[1].StartDate != "06/2021" || data.Experience[1].EndDate != "2022" {
+		t.Errorf("formatted dates = %q - %q, want 06/2021 - 2022", data.Experience[1].StartDate, data.Experience[1].EndDate)
+	}
+
+	// Templates can format dates and durations themselves
+	reg := NewRegistry()
+	tmpl, err := parseTemplate("dates", `{{range .Experience}}{{formatDate .Start "Jan 2006"}}|{{duration .Start .End}};{{end}}`)
+	if err != nil {
+		t.Fatalf("parseTemplate failed: %v", err)
+	}
+	reg.templates["dates"] = tmpl
+	latex, err := GenerateLatex(r, nil, Options{Template: "dates", Templates: reg})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if latex != "Aug 2023|2 yrs 8 mos;Jun 2021|1 yr 7 mos;" {
+		t.Errorf("output = %q", latex)
+	}
+}
+
+func TestGenerateLatexPositions(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Experience: []resume.ExperienceEntry{{
+			ID:       "cap1",
+			Company:  "Capital One",
+			Location: "McLean, VA",
+			Positions: []resume.Position{
+				{
+					ID:        "cap1-swe",
+					Title:     "Software Engineer",
+					StartDate: mustDate("August 2023"),
+					EndDate:   mustDate("Present"),
+					Bullets:   []resume.Bullet{{ID: "swe-1", Text: "Engineer bullet"}},
+				},
+				{
+					ID:        "cap1-intern",
+					Title:     "Intern",
+					Location:  "San Francisco, CA",
+					StartDate: mustDate("June 2022"),
+					EndDate:   mustDate("August 2022"),
+					Bullets:   []resume.Bullet{{ID: "intern-1", Text: "Intern bullet"}},
+				},
+			},
+		}},
+	}
+
+	for _, name := range []string{"modern", "classic", "compact"} {
+		latex, err := GenerateLatex(r, nil, Options{Template: name})
+		if err != nil {
+			t.Fatalf("%s: GenerateLatex failed: %v", name, err)
+		}
+		for _, want := range []string{"Capital One", "Software Engineer", "Intern", "San Francisco, CA", "June 2022 -- August 2022"} {
+			if !strings.Contains(latex, want) {
+				t.Errorf("%s: output missing %q", name, want)
+			}
+		}
+		if strings.Count(latex, "Capital One") != 1 {
+			t.Errorf("%s: company should be rendered once for grouped positions", name)
+		}
+		if strings.Index(latex, "Engineer bullet") > strings.Index(latex, "Intern bullet") {
+			t.Errorf("%s: positions not rendered in YAML order", name)
+		}
+	}
+
+	// Positions are ordered under the entry ID
+	order := &resume.SectionOrder{Bullets: map[string][]string{"cap1": {"cap1-intern", "cap1-swe"}}}
+	data := prepareTemplateData(r, nil, Options{Order: order})
+	exp := data.Experience[0]
+	if len(exp.Positions) != 2 || exp.Positions[0].Title != "Intern" {
+		t.Fatalf("positions = %+v, want intern first", exp.Positions)
+	}
+	if exp.StartDate != "June 2022" || exp.EndDate != "Present" {
+		t.Errorf("entry dates = %q - %q, want June 2022 - Present", exp.StartDate, exp.EndDate)
+	}
+
+	// A position with no selected bullets is dropped and the entry takes its
+	// remaining position's title and location
+	data = prepareTemplateData(r, map[string]bool{"cap1": true, "intern-1": true}, Options{})
+	exp = data.Experience[0]
+	if len(exp.Positions) != 1 || exp.Title != "Intern" || exp.Location != "San Francisco, CA" {
+		t.Errorf("filtered entry = %+v", exp)
+	}
+	if exp.StartDate != "June 2022" || exp.EndDate != "August 2022" {
+		t.Errorf("filtered dates = %q - %q, want June 2022 - August 2022", exp.StartDate, exp.EndDate)
+	}
+}
+
+func TestGenerateLatexVariants(t *testing.T) {
+	r := &resume.Resume{
+		Contact: resume.ContactInfo{Name: "Test User"},
+		Projects: []resume.ProjectEntry{{
+			ID:    "proj-1",
+			Title: "Project",
+			Bullets: []resume.Bullet{
+				{ID: "b1", Text: "Long one", Variants: map[string]string{"short": "Short one"}},
+				{ID: "b2", Text: "Long two", Variants: map[string]string{"short": "Short two"}},
+				{ID: "b3", Text: "Only wording"},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"default", Options{}, []string{"Long one", "Long two", "Only wording"}},
+		{"short", Options{Variant: "short"}, []string{"Short one", "Short two", "Only wording"}},
+		{"per bullet", Options{BulletVariants: map[string]string{"b2": "short"}}, []string{"Long one", "Short two", "Only wording"}},
+		{"per bullet overrides", Options{Variant: "short", BulletVariants: map[string]string{"b1": ""}}, []string{"Long one", "Short two", "Only wording"}},
+	}
+	for _, tt := range tests {
+		data := prepareTemplateData(r, nil, tt.opts)
+		got := data.Projects[0].Bullets
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: bullets = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPageCount(t *testing.T) {
+	tests := []struct {
+		output string
+		want   int
+	}{
+		{"Output written on resume.pdf (1 page).", 1},
+		{"Output written on /tmp/resume-pdf-123/resu\nme.pdf (2 pages).", 2},
+		{"No pages of output.", 0},
+	}
+	for _, tt := range tests {
+		if got := PageCount(tt.output); got != tt.want {
+			t.Errorf("PageCount(%q) = %d, want %d", tt.output, got, tt.want)
+		}
+	}
+}
+
+func mustDate(s string) resume.Date {
+	d, err := resume.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// markdownEscaper escapes characters Markdown would treat as markup
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// linkDestinationEscaper percent-encodes characters that end or break a
+// Markdown link destination
+var linkDestinationEscaper = strings.NewReplacer(
+	" ", "%20",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+)
+
+// markdownLink links text to a URL written with or without its scheme
+func markdownLink(text, url string) string {
+	if !strings.HasPrefix(url, "mailto:") {
+		url = href(url)
+	}
+	return fmt.Sprintf("[%s](%s)", escapeMarkdown(text), linkDestinationEscaper.Replace(url))
+}
+
+// renderMarkdown renders Markdown for READMEs and similar pages
+func renderMarkdown(data TemplateData, _ Options) ([]byte, error) {
+	var b strings.Builder
+	block := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\n\n")
+	}
+	list := func(items []string) {
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s\n", escapeMarkdown(item))
+		}
+		b.WriteString("\n")
+	}
+
+	c := data.Contact
+	block("# %s", escapeMarkdown(c.Name))
+	contact := []string{escapeMarkdown(c.Location), escapeMarkdown(c.Phone)}
+	if c.Email != "" {
+		contact = append(contact, markdownLink(c.Email, "mailto:"+c.Email))
+	}
+	if c.LinkedIn != "" {
+		contact = append(contact, markdownLink(c.LinkedIn, c.LinkedIn))
+	}
+	if c.GitHub != "" {
+		contact = append(contact, markdownLink(c.GitHub, c.GitHub))
+	}
+	if line := joinNonEmpty(" | ", contact...); line != "" {
+		block("%s", line)
+	}
+
+	for _, section := range data.Sections {
+		block("## %s", escapeMarkdown(section.Title))
+		if section.Custom != nil {
+			writeMarkdownCustom(&b, block, list, section.Custom)
+			continue
+		}
+		switch section.Name {
+		case resume.SectionSummary:
+			block("%s", escapeMarkdown(data.Summary))
+		case resume.SectionEducation:
+			for _, edu := range data.Education {
+				block("### %s", escapeMarkdown(joinNonEmpty(", ", edu.Institution, edu.Location)))
+				block("%s", escapeMarkdown(joinNonEmpty(" | ", degreeLine(edu), dateRange(edu.StartDate, edu.EndDate, " – "))))
+				details := educationDetails(edu)
+				if len(edu.Coursework) > 0 {
+					details = append(details, "Coursework: "+strings.Join(edu.Coursework, ", "))
+				}
+				if len(details) > 0 {
+					list(details)
+				}
+			}
+		case resume.SectionSkills:
+			for _, category := range data.Skills {
+				fmt.Fprintf(&b, "- **%s:** %s\n", escapeMarkdown(category.Label), escapeMarkdown(strings.Join(category.Skills, ", ")))
+			}
+			b.WriteString("\n")
+		case resume.SectionExperience:
+			for _, exp := range data.Experience {
+				block("### %s", escapeMarkdown(joinNonEmpty(", ", exp.Company, exp.Location)))
+				for _, pos := range exp.Positions {
+					dates := dateRange(pos.StartDate, pos.EndDate, " – ")
+					block("**%s**%s", escapeMarkdown(pos.Title), escapeMarkdown(prefixed(" | ", joinNonEmpty(" | ", dates, positionLocation(exp, pos)))))
+					list(pos.Bullets)
+				}
+			}
+		case resume.SectionProjects:
+			for _, proj := range data.Projects {
+				heading := escapeMarkdown(proj.Title)
+				if proj.GitHub != "" {
+					heading = markdownLink(proj.Title, proj.GitHub)
+				}
+				block("### %s", heading)
+				if proj.Technologies != "" {
+					block("*%s*", escapeMarkdown(proj.Technologies))
+				}
+				list(proj.Bullets)
+			}
+		case resume.SectionLeadership:
+			list(data.Leadership)
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+func writeMarkdownCustom(b *strings.Builder, block func(string, ...any), list func([]string), section *CustomSectionData) {
+	switch section.Layout {
+	case resume.LayoutEntries:
+		for _, item := range section.Items {
+			block("**%s**%s", escapeMarkdown(item.Title), escapeMarkdown(prefixed(", ", item.Subtitle)+prefixed(" | ", item.Date)))
+			if item.Text != "" {
+				block("%s", escapeMarkdown(item.Text))
+			}
+		}
+	case resume.LayoutTable:
+		for _, item := range section.Items {
+			fmt.Fprintf(b, "- **%s:** %s\n", escapeMarkdown(item.Key), escapeMarkdown(item.Value))
+		}
+		b.WriteString("\n")
+	default:
+		var items []string
+		for _, item := range section.Items {
+			items = append(items, item.Text)
+		}
+		list(items)
+	}
+}
+
+// prefixed returns s with prefix, or "" if s is empty
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
@@ -0,0 +1,411 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/gofont/gosmallcaps"
+)
+
+// Page geometry in points, after the modern template: a letter page with
+// half-inch margins, entries indented 0.15in and bullets nested under them
+const (
+	nativePageWidth   = 612.0
+	nativePageHeight  = 792.0
+	nativeMargin      = 36.0
+	nativeRight       = nativePageWidth - nativeMargin - 5
+	nativeEntryIndent = nativeMargin + 0.15*72
+	nativeBulletText  = nativeEntryIndent + 22
+	nativeBodySize    = 11.0
+	nativeSmallSize   = 10.0
+	nativeLeading     = 1.22
+)
+
+// Font families registered with the PDF
+const (
+	nativeFont          = "go"
+	nativeSmallCapsFont = "gosmallcaps"
+)
+
+// nativeSpan is a run of text in one font style, optionally linked to a URL
+type nativeSpan struct {
+	text  string
+	style string // gofpdf style: "", "B", "I" or "BI"
+	link  string
+}
+
+func plain(s string) nativeSpan          { return nativeSpan{text: s} }
+func bold(s string) nativeSpan           { return nativeSpan{text: s, style: "B"} }
+func italic(s string) nativeSpan         { return nativeSpan{text: s, style: "I"} }
+func linked(s string) nativeSpan         { return nativeSpan{text: s, link: href(s)} }
+func mailto(s string) nativeSpan         { return nativeSpan{text: s, link: "mailto:" + s} }
+func spans(s ...nativeSpan) []nativeSpan { return s }
+
+// nativeWord is one word of a span, laid out as a unit when wrapping
+type nativeWord struct {
+	nativeSpan
+	// space is set when whitespace separates the word from the one before
+	space bool
+}
+
+// nativeWriter lays out the resume top to bottom, starting a new page when
+// the next block does not fit
+type nativeWriter struct {
+	pdf *gofpdf.Fpdf
+	// y is the top of the next line
+	y float64
+	// font is the current family, style and size, since gofpdf writes a
+	// font change into the page on every SetFont call
+	font string
+}
+
+func newNativeWriter(title string) *nativeWriter {
+	pdf := gofpdf.New("P", "pt", "Letter", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(nativeMargin, nativeMargin, nativeMargin)
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(title, true)
+	pdf.SetCreator("resume-cli", true)
+	pdf.SetCreationDate(now())
+	pdf.SetModificationDate(now())
+	pdf.AddUTF8FontFromBytes(nativeFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(nativeFont, "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes(nativeFont, "I", goitalic.TTF)
+	pdf.AddUTF8FontFromBytes(nativeFont, "BI", gobolditalic.TTF)
+	pdf.AddUTF8FontFromBytes(nativeSmallCapsFont, "", gosmallcaps.TTF)
+
+	w := &nativeWriter{pdf: pdf}
+	w.newPage()
+	return w
+}
+
+func (w *nativeWriter) newPage() {
+	w.pdf.AddPage()
+	w.y = nativeMargin
+	w.font = ""
+}
+
+// ensure starts a new page unless height more points fit on this one
+func (w *nativeWriter) ensure(height float64) {
+	if w.y+height > nativePageHeight-nativeMargin && w.y > nativeMargin {
+		w.newPage()
+	}
+}
+
+func (w *nativeWriter) gap(height float64) {
+	w.y += height
+}
+
+// words splits spans into words, remembering where whitespace fell
+func words(spans []nativeSpan) []nativeWord {
+	var out []nativeWord
+	space := false
+	for _, span := range spans {
+		text := span.text
+		for text != "" {
+			trimmed := strings.TrimLeft(text, " \t\n")
+			if len(trimmed) < len(text) {
+				space = true
+			}
+			text = trimmed
+			if text == "" {
+				break
+			}
+			end := strings.IndexAny(text, " \t\n")
+			if end < 0 {
+				end = len(text)
+			}
+			word := span
+			word.text = text[:end]
+			out = append(out, nativeWord{nativeSpan: word, space: space && len(out) > 0})
+			space = false
+			text = text[end:]
+		}
+	}
+	return out
+}
+
+func (w *nativeWriter) setFont(style string, size float64) {
+	w.setFamily(nativeFont, style, size)
+}
+
+func (w *nativeWriter) setFamily(family, style string, size float64) {
+	font := fmt.Sprintf("%s/%s/%g", family, style, size)
+	if font != w.font {
+		w.pdf.SetFont(family, style, size)
+		w.font = font
+	}
+}
+
+func (w *nativeWriter) width(word nativeWord, size float64) float64 {
+	w.setFont(word.style, size)
+	width := w.pdf.GetStringWidth(word.text)
+	if word.space {
+		width += w.pdf.GetStringWidth(" ")
+	}
+	return width
+}
+
+// wrap breaks spans into lines no wider than width
+func (w *nativeWriter) wrap(spans []nativeSpan, size, width float64) [][]nativeWord {
+	var lines [][]nativeWord
+	var line []nativeWord
+	lineWidth := 0.0
+	for _, word := range words(spans) {
+		wordWidth := w.width(word, size)
+		if len(line) > 0 && lineWidth+wordWidth > width {
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+			word.space = false
+			wordWidth = w.width(word, size)
+		}
+		line = append(line, word)
+		lineWidth += wordWidth
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (w *nativeWriter) lineWidth(line []nativeWord, size float64) float64 {
+	total := 0.0
+	for _, word := range line {
+		total += w.width(word, size)
+	}
+	return total
+}
+
+// drawLine writes a line of words starting at x on the current line.
+// Linked words are underlined and clickable.
+func (w *nativeWriter) drawLine(x float64, line []nativeWord, size float64) {
+	baseline := w.y + size*0.92
+	for i, word := range line {
+		w.setFont(word.style, size)
+		if word.space {
+			space := w.pdf.GetStringWidth(" ")
+			if word.link != "" && i > 0 && line[i-1].link == word.link {
+				w.underline(x, x+space, baseline)
+			}
+			x += space
+		}
+		wordWidth := w.pdf.GetStringWidth(word.text)
+		w.pdf.Text(x, baseline, word.text)
+		if word.link != "" {
+			w.underline(x, x+wordWidth, baseline)
+			w.pdf.LinkString(x, w.y, wordWidth, size*nativeLeading, word.link)
+		}
+		x += wordWidth
+	}
+}
+
+func (w *nativeWriter) underline(x1, x2, baseline float64) {
+	w.pdf.SetLineWidth(0.5)
+	w.pdf.Line(x1, baseline+1.5, x2, baseline+1.5)
+}
+
+// paragraph writes spans wrapped between x and the right edge
+func (w *nativeWriter) paragraph(x float64, spans []nativeSpan, size float64) {
+	for _, line := range w.wrap(spans, size, nativeRight-x) {
+		w.ensure(size * nativeLeading)
+		w.drawLine(x, line, size)
+		w.y += size * nativeLeading
+	}
+}
+
+// row writes left-aligned spans at x and right-aligned spans against the
+// right edge, wrapping the left side if the two would collide
+func (w *nativeWriter) row(x float64, left, right []nativeSpan, size float64) {
+	rightLines := w.wrap(right, size, nativeRight-x)
+	rightWidth := 0.0
+	if len(rightLines) > 0 {
+		rightWidth = w.lineWidth(rightLines[0], size)
+	}
+	lines := w.wrap(left, size, nativeRight-x-rightWidth-12)
+	if len(lines) == 0 {
+		lines = [][]nativeWord{nil}
+	}
+	for i, line := range lines {
+		w.ensure(size * nativeLeading)
+		w.drawLine(x, line, size)
+		if i == 0 && rightWidth > 0 {
+			w.drawLine(nativeRight-rightWidth, rightLines[0], size)
+		}
+		w.y += size * nativeLeading
+	}
+}
+
+// centered writes spans centered on the page
+func (w *nativeWriter) centered(spans []nativeSpan, size float64) {
+	for _, line := range w.wrap(spans, size, nativeRight-nativeMargin) {
+		w.drawLine((nativePageWidth-w.lineWidth(line, size))/2, line, size)
+		w.y += size * nativeLeading
+	}
+}
+
+// heading writes a small-caps section title over a full-width rule, moving
+// to a new page first if the heading would end up alone at the bottom
+func (w *nativeWriter) heading(title string) {
+	const size = 14.4
+	w.gap(6)
+	w.ensure(size*nativeLeading + 4*nativeSmallSize*nativeLeading)
+	w.setFamily(nativeSmallCapsFont, "", size)
+	w.pdf.Text(nativeMargin, w.y+size*0.92, title)
+	w.y += size*nativeLeading + 1
+	w.pdf.SetLineWidth(0.4)
+	w.pdf.Line(nativeMargin, w.y, nativePageWidth-nativeMargin, w.y)
+	w.gap(4)
+}
+
+// entry keeps an entry's heading rows with its first line of content
+func (w *nativeWriter) entry(rows int) {
+	w.ensure(float64(rows)*nativeBodySize*nativeLeading + nativeSmallSize*nativeLeading)
+}
+
+func (w *nativeWriter) bullets(items []string) {
+	for _, item := range items {
+		w.ensure(nativeSmallSize * nativeLeading)
+		w.setFont("", nativeSmallSize)
+		w.pdf.Text(nativeBulletText-9, w.y+nativeSmallSize*0.92, "•")
+		w.paragraph(nativeBulletText, spans(plain(item)), nativeSmallSize)
+		w.gap(1)
+	}
+	w.gap(3)
+}
+
+// renderNativePDF lays out a PDF in Go, without a TeX engine, following the
+// modern template whatever Options.Template names. It returns the PDF and
+// its page count.
+func renderNativePDF(data TemplateData) ([]byte, int, error) {
+	c := data.Contact
+	w := newNativeWriter(c.Name)
+
+	w.setFamily(nativeSmallCapsFont, "", 24.88)
+	name := c.Name
+	w.pdf.Text((nativePageWidth-w.pdf.GetStringWidth(name))/2, w.y+24.88*0.85, name)
+	w.y += 24.88 * 1.15
+	contact := []nativeSpan{plain(c.Location), plain(c.Phone)}
+	if c.Email != "" {
+		contact = append(contact, mailto(c.Email))
+	}
+	if c.LinkedIn != "" {
+		contact = append(contact, linked(c.LinkedIn))
+	}
+	if c.GitHub != "" {
+		contact = append(contact, linked(c.GitHub))
+	}
+	var line []nativeSpan
+	for _, span := range contact {
+		if span.text == "" {
+			continue
+		}
+		if len(line) > 0 {
+			line = append(line, plain(" | "))
+		}
+		line = append(line, span)
+	}
+	w.centered(line, nativeSmallSize)
+
+	for _, section := range data.Sections {
+		w.heading(section.Title)
+		if section.Custom != nil {
+			writeNativeCustom(w, section.Custom)
+			continue
+		}
+		switch section.Name {
+		case resume.SectionSummary:
+			w.paragraph(nativeMargin, spans(plain(data.Summary)), nativeSmallSize)
+		case resume.SectionEducation:
+			for _, edu := range data.Education {
+				w.entry(2)
+				w.row(nativeEntryIndent, spans(bold(edu.Institution)), spans(plain(edu.Location)), nativeBodySize)
+				w.row(nativeEntryIndent, spans(italic(degreeLine(edu))), spans(italic(dateRange(edu.StartDate, edu.EndDate, " – "))), nativeSmallSize)
+				details := educationDetails(edu)
+				if len(edu.Coursework) > 0 {
+					details = append(details, "Coursework: "+strings.Join(edu.Coursework, ", "))
+				}
+				w.bullets(details)
+			}
+		case resume.SectionSkills:
+			for _, category := range data.Skills {
+				w.paragraph(nativeEntryIndent, spans(bold(category.Label+": "), plain(strings.Join(category.Skills, ", "))), nativeSmallSize)
+			}
+			w.gap(3)
+		case resume.SectionExperience:
+			for _, exp := range data.Experience {
+				if len(exp.Positions) > 1 {
+					w.entry(2)
+					w.row(nativeEntryIndent, spans(bold(exp.Company)), spans(plain(exp.Location)), nativeBodySize)
+					for _, pos := range exp.Positions {
+						w.entry(1)
+						title := joinNonEmpty(", ", pos.Title, positionLocation(exp, pos))
+						w.row(nativeEntryIndent, spans(italic(title)), spans(italic(dateRange(pos.StartDate, pos.EndDate, " – "))), nativeSmallSize)
+						w.bullets(pos.Bullets)
+					}
+					continue
+				}
+				w.entry(2)
+				w.row(nativeEntryIndent, spans(bold(exp.Company)), spans(plain(dateRange(exp.StartDate, exp.EndDate, " – "))), nativeBodySize)
+				w.row(nativeEntryIndent, spans(italic(exp.Title)), spans(italic(exp.Location)), nativeSmallSize)
+				w.bullets(exp.Bullets)
+			}
+		case resume.SectionProjects:
+			for _, proj := range data.Projects {
+				w.entry(1)
+				left := spans(bold(proj.Title))
+				if proj.Technologies != "" {
+					left = append(left, plain(" | "), italic(proj.Technologies))
+				}
+				var right []nativeSpan
+				if proj.GitHub != "" {
+					right = spans(linked(proj.GitHub))
+				}
+				w.row(nativeEntryIndent, left, right, nativeSmallSize)
+				w.bullets(proj.Bullets)
+			}
+		case resume.SectionLeadership:
+			w.bullets(data.Leadership)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := w.pdf.Output(&buf); err != nil {
+		return nil, 0, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return buf.Bytes(), w.pdf.PageCount(), nil
+}
+
+func writeNativeCustom(w *nativeWriter, section *CustomSectionData) {
+	switch section.Layout {
+	case resume.LayoutEntries:
+		for _, item := range section.Items {
+			w.entry(1)
+			left := spans(bold(item.Title))
+			if item.Subtitle != "" {
+				left = append(left, plain(" | "), italic(item.Subtitle))
+			}
+			w.row(nativeEntryIndent, left, spans(plain(item.Date)), nativeSmallSize)
+			if item.Text != "" {
+				w.bullets([]string{item.Text})
+			}
+		}
+	case resume.LayoutTable:
+		for _, item := range section.Items {
+			w.paragraph(nativeEntryIndent, spans(bold(item.Key+": "), plain(item.Value)), nativeSmallSize)
+		}
+		w.gap(3)
+	default:
+		var items []string
+		for _, item := range section.Items {
+			items = append(items, item.Text)
+		}
+		w.bullets(items)
+	}
+}
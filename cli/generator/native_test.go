@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+func TestRenderNativePDF(t *testing.T) {
+	r := formatTestResume()
+	pdf, err := GeneratePDF(r, nil, Options{Engine: EngineNative})
+	if err != nil {
+		t.Fatalf("GeneratePDF failed: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		t.Fatalf("output is not a PDF: %q", pdf[:min(len(pdf), 20)])
+	}
+	for _, want := range []string{
+		"/URI (mailto:test@example.com)",
+		"/URI (https://linkedin.com/in/test)",
+		"/URI (https://github.com/test/tool)",
+		"/FontFile2",
+	} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+	if pages := bytes.Count(pdf, []byte("/Type /Page\n")); pages != 1 {
+		t.Errorf("got %d pages, want 1", pages)
+	}
+}
+
+func TestRenderNativePDFPageBreaks(t *testing.T) {
+	r := formatTestResume()
+	var bullets []resume.Bullet
+	for i := 0; i < 150; i++ {
+		bullets = append(bullets, resume.Bullet{ID: fmt.Sprintf("b%d", i), Text: "Shipped a long-running improvement to a service that many teams depend on every day"})
+	}
+	r.Experience[0].Positions[0].Bullets = bullets
+
+	_, pages, err := renderNativePDF(prepareTemplateData(r, nil, Options{}))
+	if err != nil {
+		t.Fatalf("renderNativePDF failed: %v", err)
+	}
+	if pages < 3 {
+		t.Errorf("got %d pages, want the bullets to flow onto at least 3", pages)
+	}
+}
+
+func TestNativeWrap(t *testing.T) {
+	w := newNativeWriter("Test")
+	got := words([]nativeSpan{bold("Languages: "), plain("Go, Python"), plain(" and SQL")})
+	var texts []string
+	for _, word := range got {
+		if word.space {
+			texts = append(texts, "_"+word.text)
+		} else {
+			texts = append(texts, word.text)
+		}
+	}
+	if want := []string{"Languages:", "_Go,", "_Python", "_and", "_SQL"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("words = %q, want %q", texts, want)
+	}
+	if got[0].style != "B" || got[1].style != "" {
+		t.Error("words should keep their span's style")
+	}
+
+	text := strings.Repeat("word ", 60)
+	lines := w.wrap([]nativeSpan{plain(text)}, nativeSmallSize, 200)
+	if len(lines) < 2 {
+		t.Fatalf("got %d lines, want wrapping", len(lines))
+	}
+	for i, line := range lines {
+		if width := w.lineWidth(line, nativeSmallSize); width > 200 {
+			t.Errorf("line %d is %.1fpt wide, want at most 200", i, width)
+		}
+		if line[0].space {
+			t.Errorf("line %d starts with a space", i)
+		}
+	}
+}
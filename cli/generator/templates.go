@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tex
+var builtinTemplates embed.FS
+
+// DefaultTemplate is the template used when none is requested
+const DefaultTemplate = "modern"
+
+// Template is a named LaTeX template that can render TemplateData
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Builtin     bool   `json:"builtin"`
+	Engine      string `json:"engine,omitempty"`
+
+	tmpl *template.Template
+	// fontspec is set when the template loads fontspec, which needs a
+	// Unicode engine
+	fontspec bool
+}
+
+// Registry holds the LaTeX templates available for rendering
+type Registry struct {
+	templates map[string]*Template
+}
+
+// NewRegistry returns a registry containing the built-in templates
+func NewRegistry() *Registry {
+	reg := &Registry{templates: make(map[string]*Template)}
+
+	entries, err := builtinTemplates.ReadDir("templates")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read built-in templates: %v", err))
+	}
+	for _, entry := range entries {
+		source, err := builtinTemplates.ReadFile("templates/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("failed to read built-in template %s: %v", entry.Name(), err))
+		}
+		t, err := parseTemplate(templateName(entry.Name()), string(source))
+		if err != nil {
+			panic(err)
+		}
+		t.Builtin = true
+		reg.templates[t.Name] = t
+	}
+
+	return reg
+}
+
+// LoadDir adds every .tex file in dir to the registry. A user template with
+// the same name as a built-in one replaces it. A missing directory is not an error.
+func (reg *Registry) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tex" {
+			continue
+		}
+		source, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+		t, err := parseTemplate(templateName(entry.Name()), string(source))
+		if err != nil {
+			return err
+		}
+		reg.templates[t.Name] = t
+	}
+
+	return nil
+}
+
+// Get returns the named template, or the default template if name is empty
+func (reg *Registry) Get(name string) (*Template, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	t, ok := reg.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(reg.Names(), ", "))
+	}
+	return t, nil
+}
+
+// Names returns the sorted names of all registered templates
+func (reg *Registry) Names() []string {
+	names := make([]string, 0, len(reg.templates))
+	for name := range reg.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns all registered templates sorted by name
+func (reg *Registry) List() []*Template {
+	var list []*Template
+	for _, name := range reg.Names() {
+		list = append(list, reg.templates[name])
+	}
+	return list
+}
+
+// fontspecPattern matches a template loading fontspec
+var fontspecPattern = regexp.MustCompile(`\\(?:usepackage|RequirePackage)(?:\[[^\]]*\])?\{[^}]*\bfontspec\b`)
+
+// templateName derives a template name from its file name
+func templateName(fileName string) string {
+	return strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+}
+
+// parseTemplate compiles a LaTeX template and reads its metadata header.
+// Metadata is given in leading comment lines such as "% description: ..."
+// and "% engine: lualatex".
+func parseTemplate(name, source string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"escape":     escapeLaTeX,
+		"formatDate": formatDate,
+		"duration":   duration,
+	}).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	t := &Template{Name: name, tmpl: tmpl, fontspec: fontspecPattern.MatchString(source)}
+
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "%") {
+			break
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "%")), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "description":
+			t.Description = strings.TrimSpace(value)
+		case "engine":
+			t.Engine = strings.TrimSpace(value)
+			engine, err := LookupTeXEngine(t.Engine)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %w", name, err)
+			}
+			if t.fontspec && !engine.Unicode() {
+				return nil, fmt.Errorf("template %s: uses fontspec, which %s does not support", name, t.Engine)
+			}
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+func TestRegistryBuiltins(t *testing.T) {
+	reg := NewRegistry()
+
+	for _, name := range []string{"modern", "classic", "compact"} {
+		tmpl, err := reg.Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) failed: %v", name, err)
+		}
+		if !tmpl.Builtin {
+			t.Errorf("template %q should be marked built-in", name)
+		}
+		if tmpl.Description == "" {
+			t.Errorf("template %q is missing a description", name)
+		}
+	}
+
+	tmpl, err := reg.Get("")
+	if err != nil {
+		t.Fatalf("Get(\"\") failed: %v", err)
+	}
+	if tmpl.Name != DefaultTemplate {
+		t.Errorf("empty name resolved to %q, want %q", tmpl.Name, DefaultTemplate)
+	}
+
+	if _, err := reg.Get("nonexistent"); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestRegistryLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	source := "% description: Minimal test layout\n\\documentclass{article}\n\\begin{document}\nCUSTOM {{escape .Contact.Name}}\n\\end{document}\n"
+	if err := os.WriteFile(filepath.Join(dir, "minimal.tex"), []byte(source), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	reg := NewRegistry()
+	if err := reg.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+
+	tmpl, err := reg.Get("minimal")
+	if err != nil {
+		t.Fatalf("Get(minimal) failed: %v", err)
+	}
+	if tmpl.Builtin {
+		t.Error("user template should not be marked built-in")
+	}
+	if tmpl.Description != "Minimal test layout" {
+		t.Errorf("Description = %q, want %q", tmpl.Description, "Minimal test layout")
+	}
+	if _, err := reg.Get("notes"); err == nil {
+		t.Error("non-.tex files should not be registered")
+	}
+
+	r := &resume.Resume{Contact: resume.ContactInfo{Name: "A & B"}}
+	latex, err := GenerateLatex(r, nil, Options{Template: "minimal", Templates: reg})
+	if err != nil {
+		t.Fatalf("GenerateLatex failed: %v", err)
+	}
+	if !strings.Contains(latex, `CUSTOM A \& B`) {
+		t.Errorf("user template not rendered, got:\n%s", latex)
+	}
+
+	if err := reg.LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("LoadDir on a missing directory should not fail: %v", err)
+	}
+}
+
+func TestRegistryLoadDirInvalidTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.tex"), []byte("{{if .Summary}"), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	if err := NewRegistry().LoadDir(dir); err == nil {
+		t.Error("expected error for a template that does not parse")
+	}
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// textWidth is the column plain-text output wraps at
+const textWidth = 80
+
+// atsReplacer swaps typographic characters that applicant tracking systems
+// often garble for ASCII equivalents
+var atsReplacer = strings.NewReplacer(
+	"→", "->",
+	"←", "<-",
+	"↔", "<->",
+	"⇒", "=>",
+	"–", "-",
+	"—", "-",
+	"‘", "'",
+	"’", "'",
+	"“", `"`,
+	"”", `"`,
+	"…", "...",
+	"•", "-",
+	"·", "-",
+	"≤", "<=",
+	"≥", ">=",
+	"×", "x",
+	"\u00a0", " ",
+)
+
+// textWriter builds ATS-safe plain text
+type textWriter struct {
+	b strings.Builder
+}
+
+func (w *textWriter) line(s string) {
+	w.b.WriteString(atsReplacer.Replace(s))
+	w.b.WriteByte('\n')
+}
+
+func (w *textWriter) blank() {
+	w.b.WriteByte('\n')
+}
+
+// heading writes an upper-case section heading underlined with dashes
+func (w *textWriter) heading(title string) {
+	title = strings.ToUpper(atsReplacer.Replace(title))
+	w.blank()
+	w.line(title)
+	w.line(strings.Repeat("-", utf8.RuneCountInString(title)))
+}
+
+// para writes text wrapped at textWidth, with later lines indented by hang
+func (w *textWriter) para(text, hang string) {
+	for _, line := range wrap(atsReplacer.Replace(text), textWidth, hang) {
+		w.line(line)
+	}
+}
+
+// bullet writes a "- " list item with wrapped lines aligned to its text
+func (w *textWriter) bullet(text string) {
+	w.para("- "+text, "  ")
+}
+
+// wrap breaks text into lines of at most width runes at spaces. Later lines
+// start with hang. Words longer than a line are left whole.
+func wrap(text string, width int, hang string) []string {
+	var lines []string
+	var line strings.Builder
+	lineLen, words := 0, 0
+	for _, word := range strings.Fields(text) {
+		wordLen := utf8.RuneCountInString(word)
+		if words > 0 && lineLen+1+wordLen > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(hang)
+			lineLen, words = utf8.RuneCountInString(hang), 0
+		}
+		if words > 0 {
+			line.WriteByte(' ')
+			lineLen++
+		}
+		line.WriteString(word)
+		lineLen += wordLen
+		words++
+	}
+	if words > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// renderText renders plain text for pasting into application forms
+func renderText(data TemplateData, _ Options) ([]byte, error) {
+	w := &textWriter{}
+	c := data.Contact
+	w.line(strings.ToUpper(c.Name))
+	w.para(joinNonEmpty(" | ", c.Location, c.Email, c.Phone, c.LinkedIn, c.GitHub), "")
+
+	for _, section := range data.Sections {
+		w.heading(section.Title)
+		if section.Custom != nil {
+			writeTextCustom(w, section.Custom)
+			continue
+		}
+		switch section.Name {
+		case resume.SectionSummary:
+			w.para(data.Summary, "")
+		case resume.SectionEducation:
+			for i, edu := range data.Education {
+				if i > 0 {
+					w.blank()
+				}
+				w.para(joinNonEmpty(", ", edu.Institution, edu.Location), "")
+				w.para(joinNonEmpty(" | ", degreeLine(edu), dateRange(edu.StartDate, edu.EndDate, " - ")), "")
+				for _, detail := range educationDetails(edu) {
+					w.para(detail, "  ")
+				}
+				if len(edu.Coursework) > 0 {
+					w.para("Coursework: "+strings.Join(edu.Coursework, ", "), "  ")
+				}
+			}
+		case resume.SectionSkills:
+			for _, category := range data.Skills {
+				w.para(category.Label+": "+strings.Join(category.Skills, ", "), "  ")
+			}
+		case resume.SectionExperience:
+			for i, exp := range data.Experience {
+				if i > 0 {
+					w.blank()
+				}
+				w.para(joinNonEmpty(", ", exp.Company, exp.Location), "")
+				for j, pos := range exp.Positions {
+					if j > 0 {
+						w.blank()
+					}
+					w.para(joinNonEmpty(" | ", pos.Title, dateRange(pos.StartDate, pos.EndDate, " - "), positionLocation(exp, pos)), "")
+					for _, bullet := range pos.Bullets {
+						w.bullet(bullet)
+					}
+				}
+			}
+		case resume.SectionProjects:
+			for i, proj := range data.Projects {
+				if i > 0 {
+					w.blank()
+				}
+				w.para(joinNonEmpty(" | ", proj.Title, proj.Technologies, proj.GitHub), "")
+				for _, bullet := range proj.Bullets {
+					w.bullet(bullet)
+				}
+			}
+		case resume.SectionLeadership:
+			for _, item := range data.Leadership {
+				w.bullet(item)
+			}
+		}
+	}
+	return []byte(w.b.String()), nil
+}
+
+func writeTextCustom(w *textWriter, section *CustomSectionData) {
+	for _, item := range section.Items {
+		switch section.Layout {
+		case resume.LayoutEntries:
+			w.para(joinNonEmpty(" | ", joinNonEmpty(", ", item.Title, item.Subtitle), item.Date), "")
+			if item.Text != "" {
+				w.para(item.Text, "  ")
+			}
+		case resume.LayoutTable:
+			w.para(item.Key+": "+item.Value, "  ")
+		default:
+			w.bullet(item.Text)
+		}
+	}
+}
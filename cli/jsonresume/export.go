@@ -0,0 +1,335 @@
+package jsonresume
+
+import (
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// schemaURL identifies the JSON Resume schema version written
+const schemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Property orders from the JSON Resume schema
+var (
+	rootKeys      = []string{"$schema", "basics", "work", "volunteer", "education", "awards", "certificates", "publications", "skills", "languages", "interests", "references", "projects", "meta"}
+	basicsKeys    = []string{"name", "label", "image", "email", "phone", "url", "summary", "location", "profiles"}
+	locationKeys  = []string{"address", "postalCode", "city", "countryCode", "region"}
+	workKeys      = []string{"name", "location", "description", "position", "url", "startDate", "endDate", "summary", "highlights"}
+	educationKeys = []string{"institution", "url", "area", "studyType", "startDate", "endDate", "score", "courses"}
+	skillKeys     = []string{"name", "level", "keywords"}
+	projectKeys   = []string{"name", "description", "highlights", "keywords", "startDate", "endDate", "url", "roles", "entity", "type"}
+	metaKeys      = []string{"canonical", "version", "lastModified"}
+)
+
+// Export converts r to an indented JSON Resume document
+func Export(r *resume.Resume) ([]byte, error) {
+	e := exporter{extras: r.JSONResume}
+	if e.extras == nil {
+		e.extras = &resume.JSONResumeExtras{}
+	}
+
+	values := map[string]any{
+		"$schema":   schemaURL,
+		"basics":    e.basics(r),
+		"work":      e.work(r),
+		"education": e.education(r),
+		"skills":    e.skills(r),
+		"projects":  e.projects(r),
+	}
+	var sections []any
+	for _, section := range r.Sections {
+		ext := compact(map[string]any{"id": section.ID, "title": section.Title, "layout": section.Layout})
+		m := mappingFor(section)
+		for _, item := range section.Items {
+			if m == nil {
+				// Sections with no JSON Resume counterpart are kept whole
+				items, _ := ext["items"].([]any)
+				ext["items"] = append(items, customItemExt(item, nil))
+				continue
+			}
+			values[m.name] = append(objectList(values[m.name]), e.customItem(*m, item))
+		}
+		sections = append(sections, ext)
+	}
+
+	var leadership []any
+	for _, lead := range r.Leadership {
+		leadership = append(leadership, compact(map[string]any{"id": lead.ID, "text": lead.Text, "tags": lead.Tags}))
+	}
+	meta := build(metaKeys, nil, obj(e.extras.Root, "meta"))
+	meta.set(extKey, compact(map[string]any{"leadership": leadership, "sections": sections}))
+	values["meta"] = meta
+
+	doc := build(rootKeys, values, e.extras.Root)
+	data, err := marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return indent(data)
+}
+
+type exporter struct {
+	extras *resume.JSONResumeExtras
+}
+
+// build creates an object with keys in schema order, taking each value from
+// values or, failing that, extras, followed by the remaining extras
+func build(schema []string, values, extras map[string]any) *object {
+	o := newObject()
+	for _, key := range schema {
+		if !isEmpty(values[key]) {
+			o.set(key, values[key])
+		} else {
+			o.set(key, extras[key])
+		}
+	}
+	o.merge(extras)
+	return o
+}
+
+// objectList returns v as a list of objects, for appending to
+func objectList(v any) []*object {
+	list, _ := v.([]*object)
+	return list
+}
+
+func (e exporter) basics(r *resume.Resume) *object {
+	c := r.Contact
+	extras := e.extras.Basics
+	ext := make(map[string]any)
+
+	city, region := splitLocation(c.Location)
+	if joinLocation(city, region) != c.Location {
+		ext["location"] = c.Location
+	}
+	location := build(locationKeys, map[string]any{"city": city, "region": region}, obj(extras, "location"))
+
+	var profiles []any
+	for _, p := range []struct{ network, link, field string }{
+		{"LinkedIn", c.LinkedIn, "linkedin"},
+		{"GitHub", c.GitHub, "github"},
+	} {
+		if p.link == "" {
+			continue
+		}
+		url := withScheme(p.link)
+		if withoutScheme(url) != p.link {
+			ext[p.field] = p.link
+		}
+		profile := newObject()
+		profile.set("network", p.network)
+		profile.set("username", lastSegment(url))
+		profile.set("url", url)
+		profiles = append(profiles, profile)
+	}
+	for _, profile := range objects(extras, "profiles") {
+		profiles = append(profiles, profile)
+	}
+
+	basics := build(basicsKeys, map[string]any{
+		"name":     c.Name,
+		"email":    c.Email,
+		"phone":    c.Phone,
+		"summary":  r.Summary,
+		"location": location,
+		"profiles": profiles,
+	}, extras)
+	basics.set(extKey, ext)
+	return basics
+}
+
+// work writes one work item per role, so an entry with several positions
+// becomes consecutive items tied together by their entry ID
+func (e exporter) work(r *resume.Resume) []*object {
+	var items []*object
+	for _, exp := range r.Experience {
+		for _, role := range exp.Roles() {
+			location := exp.Location
+			if role.Location != "" {
+				location = role.Location
+			}
+			extras := e.extras.Items[role.ID]
+			start, end, dates := exportDates(role.StartDate, role.EndDate)
+			highlights, bullets := exportBullets(role.Bullets)
+			ext := map[string]any{"id": role.ID, "tags": role.Tags, "bullets": bullets, "dates": dates}
+			if len(exp.Positions) > 0 {
+				ext["entry"] = compact(map[string]any{
+					"id":       exp.ID,
+					"tags":     exp.Tags,
+					"location": exp.Location,
+					"title":    exp.Title,
+					"start":    exp.StartDate.Raw,
+					"end":      exp.EndDate.Raw,
+				})
+				if role.Location != "" && role.Location == exp.Location {
+					ext["location"] = role.Location
+				}
+			}
+
+			item := build(workKeys, map[string]any{
+				"name":       exp.Company,
+				"location":   location,
+				"position":   role.Title,
+				"startDate":  keepDate(extras, "startDate", start),
+				"endDate":    keepDate(extras, "endDate", end),
+				"highlights": highlights,
+			}, extras)
+			item.set(extKey, compact(ext))
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (e exporter) education(r *resume.Resume) []*object {
+	var items []*object
+	for _, edu := range r.Education {
+		extras := e.extras.Items[edu.EntryID()]
+		start, end, dates := exportDates(edu.StartDate, edu.EndDate)
+		courses, courseExt := exportBullets(edu.Coursework)
+		item := build(educationKeys, map[string]any{
+			"institution": edu.Institution,
+			"area":        edu.Focus,
+			"studyType":   edu.Degree,
+			"startDate":   keepDate(extras, "startDate", start),
+			"endDate":     keepDate(extras, "endDate", end),
+			"score":       edu.GPA,
+			"courses":     courses,
+		}, extras)
+		item.set(extKey, compact(map[string]any{
+			"id":       edu.ID,
+			"tags":     edu.Tags,
+			"courses":  courseExt,
+			"location": edu.Location,
+			"minor":    edu.Minor,
+			"honors":   edu.Honors,
+			"program":  edu.Program,
+			"dates":    dates,
+		}))
+		items = append(items, item)
+	}
+	return items
+}
+
+func (e exporter) skills(r *resume.Resume) []*object {
+	var items []*object
+	for _, category := range r.Skills {
+		var names []string
+		var skills []any
+		for _, skill := range category.Items {
+			names = append(names, skill.Name)
+			skills = append(skills, compact(map[string]any{"id": skill.ID, "tags": skill.Tags}))
+		}
+		item := build(skillKeys, map[string]any{
+			"name":     category.DisplayLabel(),
+			"keywords": names,
+		}, e.extras.Items[skillsKey(category.Name)])
+		item.set(extKey, compact(map[string]any{"name": category.Name, "label": category.Label, "items": skills}))
+		items = append(items, item)
+	}
+	return items
+}
+
+// skillsKey is the JSONResumeExtras.Items key of a skill category
+func skillsKey(name string) string {
+	return "skills/" + name
+}
+
+func (e exporter) projects(r *resume.Resume) []*object {
+	var items []*object
+	for _, proj := range r.Projects {
+		keywords := splitList(proj.Technologies)
+		highlights, bullets := exportBullets(proj.Bullets)
+		ext := map[string]any{"id": proj.ID, "tags": proj.Tags, "bullets": bullets}
+		if strings.Join(keywords, ", ") != proj.Technologies {
+			ext["technologies"] = proj.Technologies
+		}
+		var url string
+		if proj.GitHub != "" {
+			url = withScheme(proj.GitHub)
+			if !isGitHub(url) || withoutScheme(url) != proj.GitHub {
+				ext["github"] = proj.GitHub
+			}
+		}
+
+		item := build(projectKeys, map[string]any{
+			"name":       proj.Title,
+			"highlights": highlights,
+			"keywords":   keywords,
+			"url":        url,
+		}, e.extras.Items[proj.ID])
+		item.set(extKey, compact(ext))
+		items = append(items, item)
+	}
+	return items
+}
+
+// isGitHub reports whether a project URL is imported as its GitHub link
+func isGitHub(url string) bool {
+	return strings.Contains(url, "github.com")
+}
+
+// customItem converts a custom section item using the section's mapping
+func (e exporter) customItem(m sectionMapping, item resume.CustomItem) *object {
+	extras := e.extras.Items[item.ID]
+	values := make(map[string]any)
+	mapped := make(map[string]bool)
+	ext := make(map[string]any)
+	var keys []string
+	for _, f := range m.fields {
+		field, key := f[0], f[1]
+		value := *itemField(&item, field)
+		keys = append(keys, key)
+		mapped[field] = true
+
+		switch field {
+		case "date":
+			values[key] = keepDate(extras, key, exportItemDate(value))
+			if importItemDate(exportItemDate(value)) != value {
+				ext[field] = value
+			}
+		case "value":
+			if key != "keywords" {
+				values[key] = value
+				break
+			}
+			list := splitList(value)
+			values[key] = list
+			if strings.Join(list, ", ") != value {
+				ext[field] = value
+			}
+		default:
+			values[key] = value
+		}
+	}
+
+	for key, value := range customItemExt(item, mapped) {
+		ext[key] = value
+	}
+	o := build(keys, values, extras)
+	o.set(extKey, ext)
+	return o
+}
+
+// customItemExt holds an item's ID, tags and the fields not in mapped
+func customItemExt(item resume.CustomItem, mapped map[string]bool) map[string]any {
+	ext := map[string]any{"id": item.ID, "tags": item.Tags}
+	for _, field := range itemFields {
+		if !mapped[field] {
+			ext[field] = *itemField(&item, field)
+		}
+	}
+	return compact(ext)
+}
+
+// exportBullets returns the bullet texts and, in the same order, their IDs,
+// tags and variants
+func exportBullets(bullets []resume.Bullet) ([]string, []any) {
+	var texts []string
+	var ext []any
+	for _, b := range bullets {
+		texts = append(texts, b.Text)
+		ext = append(ext, compact(map[string]any{"id": b.ID, "tags": b.Tags, "variants": b.Variants}))
+	}
+	return texts, ext
+}
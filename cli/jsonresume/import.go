@@ -0,0 +1,612 @@
+package jsonresume
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// Import converts a JSON Resume document. Items without resume-cli data get
+// IDs derived from their names and tags from the skill and project keywords
+// and the taxonomy (which may be nil) that their text mentions.
+func Import(data []byte, tax *resume.Taxonomy) (*resume.Resume, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing JSON Resume: %w", err)
+	}
+	im := newImporter(doc, tax)
+
+	r := &resume.Resume{}
+	im.basics(r, obj(doc, "basics"))
+	r.Experience = im.work(objects(doc, "work"))
+	for _, item := range objects(doc, "education") {
+		r.Education = append(r.Education, im.education(item))
+	}
+	for _, item := range objects(doc, "skills") {
+		r.Skills = append(r.Skills, im.skills(item))
+	}
+	for _, item := range objects(doc, "projects") {
+		r.Projects = append(r.Projects, im.project(item))
+	}
+
+	meta := obj(doc, "meta")
+	metaExt := obj(meta, extKey)
+	for _, lead := range objects(metaExt, "leadership") {
+		r.Leadership = append(r.Leadership, resume.LeadershipEntry{
+			ID:   str(lead, "id"),
+			Text: str(lead, "text"),
+			Tags: strs(lead, "tags"),
+		})
+	}
+	r.Sections = im.sections(doc, objects(metaExt, "sections"))
+
+	root := without(doc, "$schema", "basics", "work", "education", "skills", "projects", "meta")
+	for _, m := range sectionMappings {
+		delete(root, m.name)
+	}
+	if rest := without(meta, extKey); rest != nil {
+		if root == nil {
+			root = make(map[string]any)
+		}
+		root["meta"] = rest
+	}
+	if len(root) > 0 {
+		im.extras.Root = root
+	}
+	if len(im.extras.Root) > 0 || len(im.extras.Basics) > 0 || len(im.extras.Items) > 0 {
+		r.JSONResume = im.extras
+	}
+	return r, nil
+}
+
+type importer struct {
+	tax    *resume.Taxonomy
+	extras *resume.JSONResumeExtras
+	// used holds the IDs taken so far, so generated IDs are unique
+	used map[string]bool
+	// vocab lists the terms that become tags when bullet text mentions them
+	vocab []string
+}
+
+func newImporter(doc map[string]any, tax *resume.Taxonomy) *importer {
+	im := &importer{
+		tax:    tax,
+		extras: &resume.JSONResumeExtras{},
+		used:   make(map[string]bool),
+	}
+	collectIDs(doc, false, im.used)
+
+	seen := make(map[string]bool)
+	addTerm := func(term string) {
+		if term = strings.TrimSpace(term); term != "" && !seen[term] {
+			seen[term] = true
+			im.vocab = append(im.vocab, term)
+		}
+	}
+	for _, item := range objects(doc, "skills") {
+		for _, keyword := range strs(item, "keywords") {
+			addTerm(keyword)
+		}
+	}
+	for _, item := range objects(doc, "projects") {
+		for _, keyword := range strs(item, "keywords") {
+			addTerm(keyword)
+		}
+	}
+	for _, name := range tax.Names() {
+		addTerm(name)
+		for _, alias := range tax.Lookup(name).Aliases {
+			addTerm(alias)
+		}
+	}
+	return im
+}
+
+// collectIDs adds the IDs found in resume-cli data below v to used
+func collectIDs(v any, inExt bool, used map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		if id, ok := v["id"].(string); ok && inExt && id != "" {
+			used[id] = true
+		}
+		for key, value := range v {
+			collectIDs(value, inExt || key == extKey, used)
+		}
+	case []any:
+		for _, value := range v {
+			collectIDs(value, inExt, used)
+		}
+	}
+}
+
+// unique returns id, or id with a numeric suffix if it is already taken
+func (im *importer) unique(id string) string {
+	if id == "" {
+		id = "item"
+	}
+	candidate := id
+	for n := 2; im.used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	im.used[candidate] = true
+	return candidate
+}
+
+// keep records the fields of item that were not mapped under id
+func (im *importer) keep(id string, item map[string]any, mapped ...string) {
+	rest := without(item, append(mapped, extKey)...)
+	if rest == nil {
+		return
+	}
+	if im.extras.Items == nil {
+		im.extras.Items = make(map[string]map[string]any)
+	}
+	im.extras.Items[id] = rest
+}
+
+// tag converts a term such as "Cloud Run" to a canonical tag
+func (im *importer) tag(term string) string {
+	return im.tax.Canonical(strings.ToLower(strings.Join(strings.Fields(term), "-")))
+}
+
+// tagsFor returns the tags for the vocabulary terms text mentions
+func (im *importer) tagsFor(text string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, term := range im.vocab {
+		if !mentions(text, term) && !mentions(text, strings.ReplaceAll(term, "-", " ")) {
+			continue
+		}
+		if tag := im.tag(term); !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// mentions reports whether text contains term as a whole word. Short terms
+// such as "Go" must match case too, so they are not found in ordinary words.
+func mentions(text, term string) bool {
+	if len(term) > 3 {
+		text, term = strings.ToLower(text), strings.ToLower(term)
+	}
+	for i := 0; i < len(text); {
+		k := strings.Index(text[i:], term)
+		if k < 0 {
+			return false
+		}
+		start, end := i+k, i+k+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// bulletTags returns the sorted union of the tags of bullets
+func bulletTags(bullets []resume.Bullet) []string {
+	lists := make([][]string, len(bullets))
+	for i, b := range bullets {
+		lists[i] = b.Tags
+	}
+	return unionTags(lists...)
+}
+
+// unionTags returns the sorted union of tag lists
+func unionTags(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, list := range lists {
+		for _, tag := range list {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// slug turns a name into an ID such as "acme-corp"
+func slug(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !isWordRune(r)
+	}), "-")
+}
+
+// slugWords is slug applied to the first n words of s
+func slugWords(s string, n int) string {
+	words := strings.Fields(s)
+	if len(words) > n {
+		words = words[:n]
+	}
+	return slug(strings.Join(words, " "))
+}
+
+func (im *importer) basics(r *resume.Resume, basics map[string]any) {
+	ext := obj(basics, extKey)
+	location := obj(basics, "location")
+	r.Contact = resume.ContactInfo{
+		Name:     str(basics, "name"),
+		Location: joinLocation(str(location, "city"), str(location, "region")),
+		Email:    str(basics, "email"),
+		Phone:    str(basics, "phone"),
+	}
+	r.Summary = str(basics, "summary")
+
+	rest := without(basics, "name", "email", "phone", "summary", "location", "profiles", extKey)
+	if rest == nil {
+		rest = make(map[string]any)
+	}
+	if other := without(location, "city", "region"); other != nil {
+		rest["location"] = other
+	}
+
+	var profiles []any
+	for _, profile := range objects(basics, "profiles") {
+		var field *string
+		switch strings.ToLower(str(profile, "network")) {
+		case "linkedin":
+			field = &r.Contact.LinkedIn
+		case "github":
+			field = &r.Contact.GitHub
+		}
+		url, username := str(profile, "url"), str(profile, "username")
+		if field != nil && *field == "" && url != "" &&
+			(username == "" || username == lastSegment(url)) &&
+			without(profile, "network", "username", "url") == nil {
+			*field = withoutScheme(url)
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+	if len(profiles) > 0 {
+		rest["profiles"] = profiles
+	}
+
+	for field, value := range map[string]*string{
+		"location": &r.Contact.Location,
+		"linkedin": &r.Contact.LinkedIn,
+		"github":   &r.Contact.GitHub,
+	} {
+		if raw, ok := ext[field].(string); ok {
+			*value = raw
+		}
+	}
+	if len(rest) > 0 {
+		im.extras.Basics = rest
+	}
+}
+
+// dates reads a date range from item, preferring the dates as written in
+// ext. It returns the keys that were mapped; dates that do not parse are
+// kept as extras.
+func (im *importer) dates(item, ext map[string]any, startKey, endKey string) (start, end resume.Date, mapped []string) {
+	rawStart, rawEnd := str(item, startKey), str(item, endKey)
+	start, end, startOK, endOK := importDates(rawStart, rawEnd)
+	// Dates more precise than a month are kept as written too
+	if startOK && isoDate(start) == rawStart {
+		mapped = append(mapped, startKey)
+	}
+	if endOK && isoDate(end) == rawEnd {
+		mapped = append(mapped, endKey)
+	}
+	dates := obj(ext, "dates")
+	if raw, ok := dates["start"].(string); ok {
+		start, _ = resume.ParseDate(raw)
+	}
+	if raw, ok := dates["end"].(string); ok {
+		end, _ = resume.ParseDate(raw)
+	}
+	return start, end, mapped
+}
+
+// bullets pairs texts with the IDs, tags and variants in ext. Without them,
+// IDs are derived from parentID and the text, and tags from the text.
+func (im *importer) bullets(texts []string, ext []map[string]any, parentID string) []resume.Bullet {
+	useExt := len(ext) == len(texts)
+	var bullets []resume.Bullet
+	for i, text := range texts {
+		b := resume.Bullet{Text: text}
+		if useExt {
+			b.ID = str(ext[i], "id")
+			b.Tags = strs(ext[i], "tags")
+			for name, value := range obj(ext[i], "variants") {
+				if s, ok := value.(string); ok {
+					if b.Variants == nil {
+						b.Variants = make(map[string]string)
+					}
+					b.Variants[name] = s
+				}
+			}
+		} else {
+			b.Tags = im.tagsFor(text)
+		}
+		if b.ID == "" {
+			b.ID = im.unique(parentID + "-" + slugWords(text, 4))
+		}
+		bullets = append(bullets, b)
+	}
+	return bullets
+}
+
+// work groups work items into experience entries. Items exported from one
+// entry share its ID; other consecutive items at the same company become
+// positions of one entry.
+func (im *importer) work(items []map[string]any) []resume.ExperienceEntry {
+	var entries []resume.ExperienceEntry
+	for i := 0; i < len(items); {
+		ext := obj(items[i], extKey)
+		entryExt := obj(ext, "entry")
+		company := str(items[i], "name")
+		j := i + 1
+		for ; j < len(items); j++ {
+			next := obj(items[j], extKey)
+			if entryExt != nil {
+				if str(obj(next, "entry"), "id") != str(entryExt, "id") {
+					break
+				}
+			} else if ext != nil || next != nil || company == "" || str(items[j], "name") != company {
+				break
+			}
+		}
+		entries = append(entries, im.experience(items[i:j], entryExt))
+		i = j
+	}
+	return entries
+}
+
+func (im *importer) experience(group []map[string]any, entryExt map[string]any) resume.ExperienceEntry {
+	exp := resume.ExperienceEntry{Company: str(group[0], "name")}
+	if len(group) == 1 && entryExt == nil {
+		role := im.role(group[0], slug(exp.Company))
+		exp.ID, exp.Title, exp.Location = role.ID, role.Title, role.Location
+		exp.StartDate, exp.EndDate = role.StartDate, role.EndDate
+		exp.Tags, exp.Bullets = role.Tags, role.Bullets
+		return exp
+	}
+
+	exp.ID = str(entryExt, "id")
+	if exp.ID == "" {
+		exp.ID = im.unique(slug(exp.Company))
+	}
+	exp.Location = str(group[0], "location")
+	if entryExt != nil {
+		exp.Location = str(entryExt, "location")
+		exp.Title = str(entryExt, "title")
+		exp.StartDate, _ = resume.ParseDate(str(entryExt, "start"))
+		exp.EndDate, _ = resume.ParseDate(str(entryExt, "end"))
+		exp.Tags = strs(entryExt, "tags")
+	}
+	for _, item := range group {
+		role := im.role(item, exp.ID+"-"+slug(str(item, "position")))
+		if _, ok := obj(item, extKey)["location"]; !ok && role.Location == exp.Location {
+			role.Location = ""
+		}
+		exp.Positions = append(exp.Positions, role)
+	}
+	if entryExt == nil {
+		var lists [][]string
+		for _, role := range exp.Positions {
+			lists = append(lists, role.Tags)
+		}
+		exp.Tags = unionTags(lists...)
+	}
+	return exp
+}
+
+// role reads one work item, generating its ID from fallbackID if needed
+func (im *importer) role(item map[string]any, fallbackID string) resume.Position {
+	ext := obj(item, extKey)
+	role := resume.Position{
+		ID:       str(ext, "id"),
+		Title:    str(item, "position"),
+		Location: str(item, "location"),
+		Tags:     strs(ext, "tags"),
+	}
+	if raw, ok := ext["location"].(string); ok {
+		role.Location = raw
+	}
+	if role.ID == "" {
+		role.ID = im.unique(fallbackID)
+	}
+	var mapped []string
+	role.StartDate, role.EndDate, mapped = im.dates(item, ext, "startDate", "endDate")
+	role.Bullets = im.bullets(strs(item, "highlights"), objects(ext, "bullets"), role.ID)
+	if ext == nil {
+		role.Tags = bulletTags(role.Bullets)
+	}
+	im.keep(role.ID, item, append(mapped, "name", "position", "location", "highlights")...)
+	return role
+}
+
+func (im *importer) education(item map[string]any) resume.EducationEntry {
+	ext := obj(item, extKey)
+	edu := resume.EducationEntry{
+		ID:          str(ext, "id"),
+		Institution: str(item, "institution"),
+		Location:    str(ext, "location"),
+		Degree:      str(item, "studyType"),
+		Focus:       str(item, "area"),
+		Program:     str(ext, "program"),
+		Minor:       str(ext, "minor"),
+		GPA:         str(item, "score"),
+		Honors:      str(ext, "honors"),
+		Tags:        strs(ext, "tags"),
+	}
+	if ext == nil {
+		edu.ID = im.unique(edu.EntryID())
+	}
+	var mapped []string
+	edu.StartDate, edu.EndDate, mapped = im.dates(item, ext, "startDate", "endDate")
+	edu.Coursework = im.bullets(strs(item, "courses"), objects(ext, "courses"), edu.EntryID())
+	if ext == nil {
+		edu.Tags = bulletTags(edu.Coursework)
+	}
+	im.keep(edu.EntryID(), item, append(mapped, "institution", "area", "studyType", "score", "courses")...)
+	return edu
+}
+
+func (im *importer) skills(item map[string]any) resume.SkillCategory {
+	ext := obj(item, extKey)
+	label := str(item, "name")
+	category := resume.SkillCategory{Name: str(ext, "name"), Label: str(ext, "label")}
+	if ext == nil {
+		category.Name = slug(label)
+		if category.DisplayLabel() != label {
+			category.Label = label
+		}
+	}
+
+	keywords := strs(item, "keywords")
+	skills := objects(ext, "items")
+	for i, name := range keywords {
+		skill := resume.SkillItem{Name: name}
+		if len(skills) == len(keywords) {
+			skill.ID = str(skills[i], "id")
+			skill.Tags = strs(skills[i], "tags")
+		} else {
+			skill.Tags = []string{im.tag(name)}
+		}
+		category.Items = append(category.Items, skill)
+	}
+	im.keep(skillsKey(category.Name), item, "name", "keywords")
+	return category
+}
+
+func (im *importer) project(item map[string]any) resume.ProjectEntry {
+	ext := obj(item, extKey)
+	keywords := strs(item, "keywords")
+	proj := resume.ProjectEntry{
+		ID:           str(ext, "id"),
+		Title:        str(item, "name"),
+		Technologies: strings.Join(keywords, ", "),
+		Tags:         strs(ext, "tags"),
+	}
+	if raw, ok := ext["technologies"].(string); ok {
+		proj.Technologies = raw
+	}
+	mapped := []string{"name", "keywords", "highlights"}
+	url := str(item, "url")
+	if raw, ok := ext["github"].(string); ok {
+		proj.GitHub = raw
+		mapped = append(mapped, "url")
+	} else if isGitHub(url) {
+		proj.GitHub = withoutScheme(url)
+		mapped = append(mapped, "url")
+	}
+	if proj.ID == "" {
+		proj.ID = im.unique(slug(proj.Title))
+	}
+	proj.Bullets = im.bullets(strs(item, "highlights"), objects(ext, "bullets"), proj.ID)
+	if ext == nil {
+		for _, keyword := range keywords {
+			proj.Tags = append(proj.Tags, im.tag(keyword))
+		}
+	}
+	im.keep(proj.ID, item, mapped...)
+	return proj
+}
+
+// sections reads custom sections in the order recorded in meta, followed
+// by any other JSON Resume sections with a mapping
+func (im *importer) sections(doc map[string]any, recorded []map[string]any) []resume.CustomSection {
+	var sections []resume.CustomSection
+	seen := make(map[string]bool)
+	for _, s := range recorded {
+		section := resume.CustomSection{
+			ID:     str(s, "id"),
+			Title:  str(s, "title"),
+			Layout: str(s, "layout"),
+		}
+		if m := mappingFor(section); m != nil {
+			seen[m.name] = true
+			section.Items = im.customItems(*m, section.ID, objects(doc, m.name))
+		} else {
+			section.Items = im.customItems(sectionMapping{}, section.ID, objects(s, "items"))
+		}
+		sections = append(sections, section)
+	}
+	for _, m := range sectionMappings {
+		items := objects(doc, m.name)
+		if seen[m.name] || len(items) == 0 {
+			continue
+		}
+		sections = append(sections, resume.CustomSection{
+			ID:     m.name,
+			Title:  m.title,
+			Layout: m.layout,
+			Items:  im.customItems(m, m.name, items),
+		})
+	}
+	return sections
+}
+
+// customItems reads section items using mapping m. Items of sections with
+// no mapping are stored whole, as resume-cli data.
+func (im *importer) customItems(m sectionMapping, sectionID string, items []map[string]any) []resume.CustomItem {
+	var result []resume.CustomItem
+	for _, item := range items {
+		ext := obj(item, extKey)
+		if m.name == "" {
+			ext = item
+		}
+		ci := resume.CustomItem{ID: str(ext, "id"), Tags: strs(ext, "tags")}
+		var mapped []string
+		for _, f := range m.fields {
+			field, key := itemField(&ci, f[0]), f[1]
+			switch {
+			case key == "keywords":
+				*field = strings.Join(strs(item, key), ", ")
+			case f[0] == "date":
+				raw := str(item, key)
+				*field = importItemDate(raw)
+				if exportItemDate(*field) != raw {
+					// Kept as written too, see dates
+					continue
+				}
+			default:
+				*field = str(item, key)
+			}
+			mapped = append(mapped, key)
+		}
+		for _, name := range itemFields {
+			if raw, ok := ext[name].(string); ok {
+				*itemField(&ci, name) = raw
+			}
+		}
+		if ci.ID == "" {
+			name := ci.Title
+			if name == "" {
+				name = ci.Key
+			}
+			if name == "" {
+				name = ci.Text
+			}
+			ci.ID = im.unique(sectionID + "-" + slugWords(name, 4))
+		}
+		if ext == nil {
+			ci.Tags = im.tagsFor(ci.DisplayText())
+		}
+		if m.name != "" {
+			im.keep(ci.ID, item, mapped...)
+		}
+		result = append(result, ci)
+	}
+	return result
+}
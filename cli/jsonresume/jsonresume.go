@@ -0,0 +1,161 @@
+// Package jsonresume converts resumes to and from the JSON Resume schema
+// (https://jsonresume.org/schema).
+//
+// Data with no JSON Resume counterpart, such as IDs, tags, bullet variants
+// and leadership entries, is exported under "x-resume-cli" properties on the
+// items it belongs to and on meta, which the schema allows. JSON Resume
+// fields with no counterpart in resume.yaml are imported into its
+// jsonresume block. Either way, converting back loses nothing.
+package jsonresume
+
+import (
+	"fmt"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+// extKey names the property holding resume-cli data
+const extKey = "x-resume-cli"
+
+// Format is the name of the JSON Resume format in the CLI
+const Format = "jsonresume"
+
+// isoDate converts a date to the ISO 8601 form JSON Resume uses, "2023-08"
+// or "2023". Present, empty and unparsed dates have no ISO form.
+func isoDate(d resume.Date) string {
+	switch {
+	case d.Present || !d.Parsed():
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	}
+	return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+}
+
+// importDate converts an ISO 8601 date to the form resume.yaml uses, such as
+// "August 2023"
+func importDate(iso string) (resume.Date, error) {
+	d, err := resume.ParseDate(iso)
+	if err != nil || d.IsZero() || d.Present {
+		return d, err
+	}
+	d.Raw = d.Format("January 2006")
+	return d, nil
+}
+
+// importDates converts a JSON Resume date range, where a missing end date
+// means the role is ongoing. Dates that do not parse are left empty and
+// reported as not ok.
+func importDates(start, end string) (s, e resume.Date, startOK, endOK bool) {
+	s, err := importDate(start)
+	if startOK = err == nil; !startOK {
+		s = resume.Date{}
+	}
+	if end == "" && start != "" {
+		e, _ = resume.ParseDate("Present")
+		return s, e, startOK, true
+	}
+	e, err = importDate(end)
+	if endOK = err == nil; !endOK {
+		e = resume.Date{}
+	}
+	return s, e, startOK, endOK
+}
+
+// exportDates converts a date range, returning in ext the dates as written
+// when importing the ISO form would not reproduce them
+func exportDates(start, end resume.Date) (isoStart, isoEnd string, ext map[string]any) {
+	isoStart, isoEnd = isoDate(start), isoDate(end)
+	s, e, _, _ := importDates(isoStart, isoEnd)
+	ext = make(map[string]any)
+	if s.Raw != start.Raw {
+		ext["start"] = start.Raw
+	}
+	if e.Raw != end.Raw {
+		ext["end"] = end.Raw
+	}
+	return isoStart, isoEnd, ext
+}
+
+// keepDate returns the date as written in an imported document when it
+// still means iso, so precision such as a day is not lost
+func keepDate(extras map[string]any, key, iso string) string {
+	raw := str(extras, key)
+	if d, err := resume.ParseDate(raw); err == nil && iso != "" && isoDate(d) == iso {
+		return raw
+	}
+	return iso
+}
+
+// exportItemDate converts a custom item's date to ISO form, leaving dates
+// that do not parse as written
+func exportItemDate(date string) string {
+	d, err := resume.ParseDate(date)
+	if iso := isoDate(d); err == nil && iso != "" {
+		return iso
+	}
+	return date
+}
+
+// importItemDate is the inverse of exportItemDate
+func importItemDate(date string) string {
+	d, err := importDate(date)
+	if err != nil || d.IsZero() || d.Present {
+		return date
+	}
+	return d.Raw
+}
+
+// sectionMapping ties a JSON Resume section to a custom section with the
+// same ID and layout
+type sectionMapping struct {
+	name   string
+	title  string
+	layout string
+	// fields pairs custom item fields with JSON Resume properties
+	fields [][2]string
+}
+
+// sectionMappings lists the JSON Resume sections kept as custom sections
+var sectionMappings = []sectionMapping{
+	{"volunteer", "Volunteer", resume.LayoutEntries, [][2]string{{"title", "position"}, {"subtitle", "organization"}, {"text", "summary"}}},
+	{"awards", "Awards", resume.LayoutEntries, [][2]string{{"title", "title"}, {"subtitle", "awarder"}, {"date", "date"}, {"text", "summary"}}},
+	{"certificates", "Certificates", resume.LayoutEntries, [][2]string{{"title", "name"}, {"subtitle", "issuer"}, {"date", "date"}}},
+	{"publications", "Publications", resume.LayoutEntries, [][2]string{{"title", "name"}, {"subtitle", "publisher"}, {"date", "releaseDate"}, {"text", "summary"}}},
+	{"languages", "Languages", resume.LayoutTable, [][2]string{{"key", "language"}, {"value", "fluency"}}},
+	{"interests", "Interests", resume.LayoutTable, [][2]string{{"key", "name"}, {"value", "keywords"}}},
+	{"references", "References", resume.LayoutEntries, [][2]string{{"title", "name"}, {"text", "reference"}}},
+}
+
+// mappingFor returns the mapping for a custom section, or nil if the
+// section has no JSON Resume counterpart
+func mappingFor(section resume.CustomSection) *sectionMapping {
+	for i, m := range sectionMappings {
+		if m.name == section.ID && m.layout == section.LayoutName() {
+			return &sectionMappings[i]
+		}
+	}
+	return nil
+}
+
+// itemFields are the custom item fields, in the order they are written
+var itemFields = []string{"text", "title", "subtitle", "date", "key", "value"}
+
+// itemField returns a pointer to the named custom item field
+func itemField(item *resume.CustomItem, field string) *string {
+	switch field {
+	case "text":
+		return &item.Text
+	case "title":
+		return &item.Title
+	case "subtitle":
+		return &item.Subtitle
+	case "date":
+		return &item.Date
+	case "key":
+		return &item.Key
+	case "value":
+		return &item.Value
+	}
+	return nil
+}
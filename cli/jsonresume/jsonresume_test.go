@@ -0,0 +1,323 @@
+package jsonresume
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/resume"
+	"gopkg.in/yaml.v3"
+)
+
+const roundTripFixture = `
+contact:
+  name: Test User
+  location: Columbus, OH
+  email: test@example.com
+  phone: 555-0100
+  linkedin: linkedin.com/in/test
+  github: https://github.com/test
+
+summary: Backend engineer
+
+education:
+  - institution: State University
+    location: Columbus, Ohio
+    degree: B.S. Computer Science
+    minor: Psychology
+    gpa: 3.9/4.0
+    end_date: May 2020
+    coursework:
+      - id: course-os
+        text: Operating Systems
+        tags: [systems]
+
+skills:
+  - name: languages
+    label: Languages
+    items:
+      - name: Go
+        tags: [go, backend]
+      - id: skill-py
+        name: Python
+        tags: [python]
+  - name: tools
+    items:
+      - name: Docker
+        tags: []
+
+experience:
+  - id: acme
+    company: Acme
+    location: Remote
+    tags: [backend]
+    positions:
+      - id: acme-senior
+        title: Senior Engineer
+        start_date: Jan 2022
+        end_date: Present
+        tags: [lead]
+        bullets:
+          - id: acme-senior-1
+            text: Led the platform team
+            tags: [leadership]
+            variants:
+              short: Led platform
+      - id: acme-engineer
+        title: Engineer
+        location: New York, NY
+        start_date: Aug 2020
+        end_date: Dec 2021
+        tags: []
+        bullets:
+          - id: acme-engineer-1
+            text: Built services in Go
+            tags: [go]
+  - id: initech
+    title: Intern
+    company: Initech
+    location: Austin, TX
+    start_date: Summer 2019
+    end_date: "2019"
+    tags: []
+    bullets:
+      - id: initech-1
+        text: Wrote reports
+        tags: []
+
+projects:
+  - id: tool
+    title: Tool
+    technologies: Go, SQLite
+    github: github.com/test/tool
+    tags: [go]
+    bullets:
+      - id: tool-1
+        text: Made a tool
+        tags: [go]
+  - id: site
+    title: Site
+    technologies: Hugo
+    github: example.com
+    tags: []
+    bullets:
+      - id: site-1
+        text: Made a site
+        tags: []
+
+leadership:
+  - id: club
+    text: Club president
+    tags: [leadership]
+
+sections:
+  - id: awards
+    title: Honors
+    layout: entries
+    items:
+      - id: award-1
+        title: Best Paper
+        subtitle: ACM
+        date: Jun 2021
+        tags: [research]
+  - id: hobbies
+    title: Hobbies
+    items:
+      - id: hobby-1
+        text: Climbing
+        tags: []
+  - id: languages
+    title: Languages
+    layout: table
+    items:
+      - id: lang-en
+        key: English
+        value: Native
+        tags: []
+`
+
+func TestRoundTrip(t *testing.T) {
+	var r resume.Resume
+	if err := yaml.Unmarshal([]byte(roundTripFixture), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := Export(&r)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	imported, err := Import(data, nil)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	want, err := resume.MarshalResume(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := resume.MarshalResume(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("round trip changed the resume:\n got:\n%s\nwant:\n%s\nJSON:\n%s", got, want, data)
+	}
+}
+
+func TestExportSchema(t *testing.T) {
+	var r resume.Resume
+	if err := yaml.Unmarshal([]byte(roundTripFixture), &r); err != nil {
+		t.Fatal(err)
+	}
+	data, err := Export(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	basics := obj(doc, "basics")
+	if got := obj(basics, "location"); str(got, "city") != "Columbus" || str(got, "region") != "OH" {
+		t.Errorf("basics.location = %v", got)
+	}
+	work := objects(doc, "work")
+	if len(work) != 3 {
+		t.Fatalf("got %d work items, want one per role", len(work))
+	}
+	if str(work[0], "startDate") != "2022-01" || work[0]["endDate"] != nil {
+		t.Errorf("work[0] dates = %v, %v", work[0]["startDate"], work[0]["endDate"])
+	}
+	if str(work[1], "location") != "New York, NY" || str(work[0], "location") != "Remote" {
+		t.Errorf("work locations = %q, %q", work[0]["location"], work[1]["location"])
+	}
+	if got := strs(objects(doc, "projects")[0], "keywords"); !reflect.DeepEqual(got, []string{"Go", "SQLite"}) {
+		t.Errorf("project keywords = %v", got)
+	}
+	if got := objects(doc, "awards"); len(got) != 1 || str(got[0], "date") != "2021-06" || str(got[0], "awarder") != "ACM" {
+		t.Errorf("awards = %v", got)
+	}
+	if got := objects(doc, "languages"); len(got) != 1 || str(got[0], "language") != "English" {
+		t.Errorf("languages = %v", got)
+	}
+	if strings.Contains(string(data), `&`) {
+		t.Error("export escapes HTML characters")
+	}
+}
+
+const foreignFixture = `{
+  "basics": {
+    "name": "Jane Doe",
+    "label": "Engineer",
+    "location": {"city": "Berlin", "countryCode": "DE"},
+    "profiles": [
+      {"network": "GitHub", "username": "jane", "url": "https://github.com/jane"},
+      {"network": "Twitter", "username": "jd", "url": "https://twitter.com/jd"}
+    ]
+  },
+  "work": [
+    {"name": "Acme Corp", "position": "Staff Engineer", "startDate": "2021-03", "highlights": ["Migrated billing to Kafka and Go"]},
+    {"name": "Acme Corp", "position": "Engineer", "startDate": "2019-01", "endDate": "2021-02", "description": "Payments", "highlights": ["Built the payments API"]},
+    {"name": "Globex", "position": "Intern", "startDate": "2018", "endDate": "2018", "highlights": ["Wrote docs", "Wrote docs"]}
+  ],
+  "skills": [{"name": "Backend", "level": "Expert", "keywords": ["Go", "Kafka", "Cloud Run"]}],
+  "projects": [{"name": "CLI Tool", "url": "https://github.com/jane/cli", "keywords": ["Go"], "highlights": ["Shipped a CLI in Go"]}],
+  "awards": [{"title": "Hackathon Winner", "date": "2020-05-01", "awarder": "ACME"}],
+  "meta": {"version": "v1"}
+}`
+
+func TestImportGeneratesIDsAndTags(t *testing.T) {
+	tax, err := resume.NewTaxonomy(map[string]*resume.TagDef{"golang": {Aliases: []string{"go"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Import([]byte(foreignFixture), tax)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Contact.Location != "Berlin" || r.Contact.GitHub != "github.com/jane" {
+		t.Errorf("contact = %+v", r.Contact)
+	}
+	if len(r.Experience) != 2 {
+		t.Fatalf("got %d experience entries, want consecutive Acme roles grouped", len(r.Experience))
+	}
+	acme := r.Experience[0]
+	if acme.ID != "acme-corp" || len(acme.Positions) != 2 || acme.Positions[0].ID != "acme-corp-staff-engineer" {
+		t.Errorf("acme = %+v", acme)
+	}
+	if !acme.Positions[0].EndDate.Present || acme.Positions[0].StartDate.Raw != "March 2021" {
+		t.Errorf("staff dates = %v - %v", acme.Positions[0].StartDate, acme.Positions[0].EndDate)
+	}
+	bullet := acme.Positions[0].Bullets[0]
+	if bullet.ID != "acme-corp-staff-engineer-migrated-billing-to-kafka" {
+		t.Errorf("bullet ID = %q", bullet.ID)
+	}
+	if want := []string{"golang", "kafka"}; !reflect.DeepEqual(bullet.Tags, want) {
+		t.Errorf("bullet tags = %v, want %v", bullet.Tags, want)
+	}
+	globex := r.Experience[1]
+	if globex.Bullets[0].ID == globex.Bullets[1].ID {
+		t.Errorf("duplicate bullet IDs %q", globex.Bullets[0].ID)
+	}
+	if got := r.Skills[0].Items[2].Tags; !reflect.DeepEqual(got, []string{"cloud-run"}) {
+		t.Errorf("skill tags = %v", got)
+	}
+	if r.Projects[0].ID != "cli-tool" || r.Projects[0].GitHub != "github.com/jane/cli" {
+		t.Errorf("project = %+v", r.Projects[0])
+	}
+	if len(r.Sections) != 1 || r.Sections[0].Items[0].Date != "May 2020" {
+		t.Errorf("sections = %+v", r.Sections)
+	}
+
+	// Fields with no counterpart survive another export
+	data, err := Export(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	basics := obj(doc, "basics")
+	if str(basics, "label") != "Engineer" || str(obj(basics, "location"), "countryCode") != "DE" {
+		t.Errorf("basics = %v", basics)
+	}
+	if profiles := objects(basics, "profiles"); len(profiles) != 2 || str(profiles[1], "network") != "Twitter" {
+		t.Errorf("profiles = %v", profiles)
+	}
+	if got := str(objects(doc, "work")[1], "description"); got != "Payments" {
+		t.Errorf("work[1].description = %q", got)
+	}
+	if got := str(objects(doc, "skills")[0], "level"); got != "Expert" {
+		t.Errorf("skills[0].level = %q", got)
+	}
+	if got := str(obj(doc, "meta"), "version"); got != "v1" {
+		t.Errorf("meta.version = %q", got)
+	}
+	if got := str(objects(doc, "awards")[0], "date"); got != "2020-05-01" {
+		t.Errorf("awards[0].date = %q", got)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text, term string
+		want       bool
+	}{
+		{"Built services in Go", "Go", true},
+		{"Going further", "Go", false},
+		{"we go home", "Go", false},
+		{"Used kafka streams", "Kafka", true},
+		{"Kafkaesque design", "Kafka", false},
+		{"Deployed to Cloud Run.", "Cloud Run", true},
+		{"Wrote C++ code", "C++", true},
+	}
+	for _, tt := range tests {
+		if got := mentions(tt.text, tt.term); got != tt.want {
+			t.Errorf("mentions(%q, %q) = %v, want %v", tt.text, tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package jsonresume
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// object is a JSON object that writes its keys in the order they were set,
+// so exported documents follow the schema's field order
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]any)}
+}
+
+// set adds key unless value is empty, keeping the position of a key that
+// is set again
+func (o *object) set(key string, value any) {
+	if isEmpty(value) {
+		return
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// has reports whether key is set
+func (o *object) has(key string) bool {
+	_, ok := o.values[key]
+	return ok
+}
+
+// merge sets the keys of extra that are not already set, in sorted order
+func (o *object) merge(extra map[string]any) {
+	for _, key := range sortedKeys(extra) {
+		if !o.has(key) {
+			o.set(key, extra[key])
+		}
+	}
+}
+
+func (o *object) empty() bool {
+	return len(o.keys) == 0
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshal encodes v without escaping HTML characters, so text such as
+// "Frameworks & Tools" stays readable
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// indent formats a compact JSON document with two-space indentation
+func indent(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// compact returns m without its empty values
+func compact(m map[string]any) map[string]any {
+	for key, value := range m {
+		if isEmpty(value) {
+			delete(m, key)
+		}
+	}
+	return m
+}
+
+// isEmpty reports whether a value is worth leaving out of a document
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	case []*object:
+		return len(v) == 0
+	case []map[string]any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	case map[string]string:
+		return len(v) == 0
+	case *object:
+		return v == nil || v.empty()
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// str returns the string at key, or "" if it is missing or not a string
+func str(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// strs returns the strings in the list at key, skipping other values
+func strs(m map[string]any, key string) []string {
+	list, _ := m[key].([]any)
+	var result []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// objects returns the objects in the list at key, skipping other values
+func objects(m map[string]any, key string) []map[string]any {
+	list, _ := m[key].([]any)
+	var result []map[string]any
+	for _, item := range list {
+		if obj, ok := item.(map[string]any); ok {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// obj returns the object at key, or nil
+func obj(m map[string]any, key string) map[string]any {
+	o, _ := m[key].(map[string]any)
+	return o
+}
+
+// without returns a copy of m without the given keys, or nil if nothing
+// is left
+func without(m map[string]any, keys ...string) map[string]any {
+	rest := make(map[string]any)
+	for key, value := range m {
+		rest[key] = value
+	}
+	for _, key := range keys {
+		delete(rest, key)
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return rest
+}
+
+// withScheme turns a link written without a scheme, like
+// github.com/user, into a URL
+func withScheme(link string) string {
+	if link == "" || strings.Contains(link, "://") {
+		return link
+	}
+	return "https://" + link
+}
+
+// withoutScheme is the inverse of withScheme
+func withoutScheme(url string) string {
+	return strings.TrimPrefix(url, "https://")
+}
+
+// lastSegment returns the last path segment of a URL, used as a username
+func lastSegment(url string) string {
+	parts := strings.Split(strings.TrimRight(url, "/"), "/")
+	return parts[len(parts)-1]
+}
+
+// splitLocation splits "City, Region" into its parts
+func splitLocation(location string) (city, region string) {
+	city, region, _ = strings.Cut(location, ",")
+	return strings.TrimSpace(city), strings.TrimSpace(region)
+}
+
+// joinLocation is the inverse of splitLocation
+func joinLocation(city, region string) string {
+	if city != "" && region != "" {
+		return city + ", " + region
+	}
+	return city + region
+}
+
+// splitList splits a comma-separated list such as a project's technologies
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
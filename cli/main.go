@@ -2,18 +2,21 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/evanqhuang/resume-cli/config"
 	"github.com/evanqhuang/resume-cli/generator"
+	"github.com/evanqhuang/resume-cli/jsonresume"
 	"github.com/evanqhuang/resume-cli/matching"
 	"github.com/evanqhuang/resume-cli/resume"
 	"github.com/evanqhuang/resume-cli/server"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -30,13 +33,36 @@ const (
 )
 
 var (
-	resumePath  string
-	outputFile  string
-	jobDescFile string
-	jobDescText string
-	itemIDs     []string
-	itemTags    []string
-	serverPort  int
+	resumePath    string
+	outputFile    string
+	jobDescFile   string
+	jobDescText   string
+	itemIDs       []string
+	itemTags      []string
+	itemQuery     string
+	serverPort    int
+	templateName  string
+	templateDir   string
+	orderFile     string
+	sectionNames  []string
+	dateFormat    string
+	chronological bool
+	variant       string
+	profileName   string
+	outputFormat  string
+	pdfEngine     string
+
+	// export and import flags
+	exportFormat  string
+	exportOutput  string
+	exportOrdered bool
+	importFrom    string
+	importForce   bool
+
+	// profile save flags
+	profileSkills      []string
+	profileTemplate    string
+	profileDescription string
 )
 
 func main() {
@@ -64,6 +90,12 @@ func main() {
 	rootCmd.AddCommand(matchCmd())
 	rootCmd.AddCommand(generateCmd())
 	rootCmd.AddCommand(listCmd())
+	rootCmd.AddCommand(templatesCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(tagsCmd())
+	rootCmd.AddCommand(profileCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(importCmd())
 	rootCmd.AddCommand(serveCmd())
 
 	if err := rootCmd.Execute(); err != nil {
@@ -102,25 +134,158 @@ func matchCmd() *cobra.Command {
 func generateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "generate",
-		Short: "Generate resume PDF",
-		Long:  "Generate a LaTeX resume and compile it to PDF",
+		Short: "Generate resume PDF, Markdown, plain text, HTML or Word",
+		Long:  "Generate a LaTeX resume and compile it to PDF, or render it as Markdown, plain text, a standalone HTML page or a Word document",
 		RunE:  runGenerate,
 	}
 
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "resume.pdf", "Output PDF file path")
-	cmd.Flags().StringSliceVar(&itemIDs, "ids", []string{}, "Comma-separated list of item IDs to include")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default: resume.<format>)")
+	cmd.Flags().StringVar(&outputFormat, "format", generator.FormatPDF, "Output format: pdf, md (Markdown), txt (ATS-safe plain text), html (standalone web page) or docx (Word)")
+	cmd.Flags().StringSliceVar(&itemIDs, "ids", []string{}, "Comma-separated list of item IDs to include; an entry ID includes its bullets, -id excludes, id:variant picks a bullet's wording")
 	cmd.Flags().StringSliceVar(&itemTags, "tags", []string{}, "Comma-separated list of tags to filter items")
+	cmd.Flags().StringVarP(&itemQuery, "query", "q", "", "Boolean tag query, e.g. \"go AND (kafka OR aws) AND NOT internship\"")
+	cmd.Flags().StringVarP(&templateName, "template", "t", generator.DefaultTemplate, "Name of the LaTeX template to render")
+	cmd.Flags().StringVar(&templateDir, "template-dir", "", "Directory of user templates (default: templates/ next to resume.yaml)")
+	cmd.Flags().StringVar(&orderFile, "order", "", "Path to order.yaml (default: order.yaml next to resume.yaml)")
+	cmd.Flags().StringSliceVar(&sectionNames, "sections", []string{}, "Comma-separated section sequence; omitted sections are hidden (e.g. projects,experience,skills)")
+	cmd.Flags().StringVar(&dateFormat, "date-format", "", "Go time layout for dates, e.g. \"Jan 2006\" or \"01/2006\" (default: as written)")
+	cmd.Flags().BoolVar(&chronological, "chronological", false, "Order experience and education newest first, ignoring order.yaml for those sections")
+	cmd.Flags().StringVar(&variant, "variant", "", "Bullet wording variant, e.g. short; \"auto\" switches to short if the resume runs past one page")
+	cmd.Flags().StringVarP(&profileName, "profile", "p", "", "Named profile from profiles.yaml; --ids, --query, --tags, --template and --variant override it")
+	cmd.Flags().StringVar(&pdfEngine, "engine", "", "PDF engine: xelatex, lualatex, pdflatex, tectonic or native (default: the template's engine, or native if it is not installed; otherwise $"+generator.EngineEnv+", then the first TeX engine installed, then native)")
 
 	return cmd
 }
 
-func listCmd() *cobra.Command {
+func templatesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "List available LaTeX templates",
+		Long:  "Display the built-in templates and any user templates found in the template directory",
+		RunE:  runTemplates,
+	}
+
+	cmd.Flags().StringVar(&templateDir, "template-dir", "", "Directory of user templates (default: templates/ next to resume.yaml)")
+
+	return cmd
+}
+
+func validateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check resume.yaml, order.yaml and tags.yaml for mistakes",
+		Long:  "Report unknown fields, missing or duplicate IDs, order.yaml entries that reference missing items, and conflicting tags.yaml aliases. Exits non-zero if any problems are found.",
+		RunE:  runValidate,
+	}
+
+	cmd.Flags().StringVar(&orderFile, "order", "", "Path to order.yaml (default: order.yaml next to resume.yaml)")
+
+	return cmd
+}
+
+func tagsCmd() *cobra.Command {
 	return &cobra.Command{
+		Use:   "tags",
+		Short: "Show tag usage and check tags against tags.yaml",
+		Long:  "Print how many items use each tag, with aliases folded into their canonical tag. Flags tags missing from tags.yaml and tags that look like duplicates of each other.",
+		RunE:  runTags,
+	}
+}
+
+func profileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage saved selection profiles",
+		Long:  "Save, list, show and delete named profiles stored in profiles.yaml next to resume.yaml",
+	}
+
+	saveCmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a profile, replacing any profile with the same name",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProfileSave,
+	}
+	saveCmd.Flags().StringSliceVar(&itemIDs, "ids", []string{}, "Comma-separated list of item IDs to include; an entry ID includes its bullets, -id excludes, id:variant picks a bullet's wording")
+	saveCmd.Flags().StringSliceVar(&itemTags, "tags", []string{}, "Comma-separated list of tags to filter items")
+	saveCmd.Flags().StringVarP(&itemQuery, "query", "q", "", "Boolean tag query, e.g. \"go AND NOT internship\"")
+	saveCmd.Flags().StringSliceVar(&profileSkills, "skills", []string{}, "Comma-separated list of skill IDs or names to include")
+	saveCmd.Flags().StringSliceVar(&sectionNames, "sections", []string{}, "Comma-separated section sequence; omitted sections are hidden")
+	saveCmd.Flags().StringVar(&profileTemplate, "template", "", "Name of the LaTeX template to render")
+	saveCmd.Flags().StringVar(&variant, "variant", "", "Bullet wording variant, e.g. short or auto")
+	saveCmd.Flags().StringVar(&profileDescription, "description", "", "Short description shown by profile list")
+	saveCmd.Flags().StringVar(&templateDir, "template-dir", "", "Directory of user templates (default: templates/ next to resume.yaml)")
+
+	cmd.AddCommand(saveCmd)
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List saved profiles",
+		Args:  cobra.NoArgs,
+		RunE:  runProfileList,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <name>",
+		Short: "Print a saved profile",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProfileShow,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved profile",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProfileDelete,
+	})
+
+	return cmd
+}
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the resume to another format",
+		Long:  "Convert resume.yaml to JSON Resume (jsonresume.org), optionally applying a selection and order.yaml",
+		Args:  cobra.NoArgs,
+		RunE:  runExport,
+	}
+
+	cmd.Flags().StringVar(&exportFormat, "format", jsonresume.Format, "Output format (available: jsonresume)")
+	cmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().StringSliceVar(&itemIDs, "ids", []string{}, "Comma-separated list of item IDs to include; an entry ID includes its bullets, -id excludes")
+	cmd.Flags().StringSliceVar(&itemTags, "tags", []string{}, "Comma-separated list of tags to filter items")
+	cmd.Flags().StringVarP(&itemQuery, "query", "q", "", "Boolean tag query, e.g. \"go AND (kafka OR aws) AND NOT internship\"")
+	cmd.Flags().StringVarP(&profileName, "profile", "p", "", "Named profile from profiles.yaml; --ids, --query and --tags override it")
+	cmd.Flags().BoolVar(&exportOrdered, "ordered", false, "Sort entries and bullets by order.yaml and leave out hidden sections")
+	cmd.Flags().StringVar(&orderFile, "order", "", "Path to order.yaml (default: order.yaml next to resume.yaml)")
+	cmd.Flags().StringSliceVar(&sectionNames, "sections", []string{}, "Comma-separated section sequence; omitted sections are left out")
+
+	return cmd
+}
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a resume from another format",
+		Long:  "Convert a JSON Resume (jsonresume.org) document to resume.yaml, generating IDs and tags where it has none",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runImport,
+	}
+
+	cmd.Flags().StringVar(&importFrom, "from", jsonresume.Format, "Input format (available: jsonresume)")
+	cmd.Flags().BoolVar(&importForce, "force", false, "Overwrite an existing resume file")
+
+	return cmd
+}
+
+func listCmd() *cobra.Command {
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all resume items with IDs and tags",
 		Long:  "Display all resume items grouped by section with their IDs and tags",
 		RunE:  runList,
 	}
+
+	cmd.Flags().StringVarP(&itemQuery, "query", "q", "", "Only list items matching a boolean tag query, e.g. \"go AND NOT section:projects\"")
+
+	return cmd
 }
 
 func runMatch(cmd *cobra.Command, args []string) error {
@@ -201,6 +366,12 @@ func runMatch(cmd *cobra.Command, args []string) error {
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
+	if err := generator.ValidateFormat(outputFormat); err != nil {
+		return err
+	}
+	if outputFile == "" {
+		outputFile = "resume." + outputFormat
+	}
 	// Validate resume file exists
 	if _, err := os.Stat(resumePath); os.IsNotExist(err) {
 		return fmt.Errorf("resume file not found: %s", resumePath)
@@ -213,55 +384,331 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load resume: %w", err)
 	}
 
-	// Determine which items to include
-	var selectedIDs map[string]bool
-	if len(itemIDs) > 0 {
-		selectedIDs = r.FilterByIDs(itemIDs)
-		fmt.Printf("%sFiltering by IDs: %s%s\n", colorYellow, strings.Join(itemIDs, ", "), colorReset)
+	// Load the profile, if any; explicit flags take precedence over it
+	profile, err := loadProfile(r, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if profile != nil {
+		if profile.Template != "" && !cmd.Flags().Changed("template") {
+			templateName = profile.Template
+		}
+		if profile.Variant != "" && !cmd.Flags().Changed("variant") {
+			variant = profile.Variant
+		}
+	}
+
+	selectedIDs, bulletVariants, err := resolveSelection(r, profile, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	// Load section order, shared with the web UI
+	order, orderPath, err := loadOrder(r, profile)
+	if err != nil {
+		return err
+	}
+	if chronological {
+		byDate := resume.GetChronologicalOrder(r, time.Now())
+		order.Experience = byDate.Experience
+		order.Education = byDate.Education
+	}
+	if err := r.ValidateSections(order.Sections); err != nil {
+		return fmt.Errorf("invalid sections in %s: %w", orderPath, err)
+	}
+	if err := r.ValidateSections(sectionNames); err != nil {
+		return err
+	}
+	if len(sectionNames) > 0 {
+		fmt.Printf("%sSections: %s%s\n", colorYellow, strings.Join(sectionNames, ", "), colorReset)
+	}
+
+	if err := r.ValidateVariant(variant); err != nil {
+		return err
+	}
+	for id, name := range bulletVariants {
+		if err := r.ValidateVariant(name); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+	}
+
+	registry, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	tmpl, err := registry.Get(templateName)
+	if err != nil {
+		return err
+	}
+	opts := generator.Options{
+		Template:       templateName,
+		Templates:      registry,
+		Order:          order,
+		Sections:       sectionNames,
+		DateFormat:     dateFormat,
+		Variant:        variant,
+		BulletVariants: bulletVariants,
+	}
+
+	if outputFormat == generator.FormatPDF {
+		engine, err := generator.ResolveEngine(pdfEngine, tmpl)
+		if err != nil {
+			return err
+		}
+		opts.Engine = engine
+		if engine == generator.EngineNative {
+			switch {
+			case pdfEngine == "" && tmpl.Engine != "":
+				fmt.Printf("%sTemplate %s requires %s, which is not installed; using the native PDF renderer%s\n", colorYellow, templateName, tmpl.Engine, colorReset)
+			case pdfEngine == "" && os.Getenv(generator.EngineEnv) == "":
+				fmt.Printf("%sNo TeX engine found; using the native PDF renderer%s\n", colorYellow, colorReset)
+			}
+		} else {
+			texEngine, err := generator.LookupTeXEngine(engine)
+			if err != nil {
+				return err
+			}
+			if _, err := texEngine.Find(); err != nil {
+				return fmt.Errorf("%w, or use --engine native", err)
+			}
+			// Keep the LaTeX source beside the PDF for hand edits
+			opts.TeXFile = strings.TrimSuffix(outputFile, ".pdf") + ".tex"
+			fmt.Printf("%sCompiling PDF with %s and template %s...%s\n", colorCyan, engine, templateName, colorReset)
+		}
+	}
+
+	if opts.TeXFile == "" {
+		fmt.Printf("%sRendering %s...%s\n", colorCyan, outputFormat, colorReset)
+	}
+	content, err := generator.Render(outputFormat, r, selectedIDs, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+	if opts.TeXFile != "" {
+		fmt.Printf("%sWrote LaTeX to: %s%s\n", colorGreen, opts.TeXFile, colorReset)
+	}
+	fmt.Printf("%s✓ Successfully generated: %s%s\n", colorGreen, outputFile, colorReset)
+	return nil
+}
+
+// loadProfile loads the profile named by --profile, or returns nil if none
+// was given
+func loadProfile(r *resume.Resume, progress io.Writer) (*resume.Profile, error) {
+	if profileName == "" {
+		return nil, nil
+	}
+	profiles, err := resume.LoadProfiles(resume.ProfilesPath(resumePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profiles: %w", err)
+	}
+	profile, err := profiles.Get(profileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.Validate(r); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profileName, err)
+	}
+	fmt.Fprintf(progress, "%sUsing profile: %s%s\n", colorYellow, profileName, colorReset)
+	return profile, nil
+}
+
+// resolveSelection determines which items to include from --ids, --query,
+// --tags or the profile, in that order of precedence. An empty selection
+// includes everything.
+func resolveSelection(r *resume.Resume, profile *resume.Profile, progress io.Writer) (map[string]bool, map[string]string, error) {
+	ids, bulletVariants := resume.SplitVariants(itemIDs)
+	switch {
+	case len(ids) > 0:
+		if err := r.Index().Check(ids); err != nil {
+			return nil, nil, err
+		}
+		selectedIDs := r.FilterByIDs(ids)
+		fmt.Fprintf(progress, "%sFiltering by IDs: %s%s\n", colorYellow, strings.Join(itemIDs, ", "), colorReset)
 		if len(selectedIDs) == 0 {
-			return fmt.Errorf("no items found matching the specified IDs")
+			return nil, nil, fmt.Errorf("no items found matching the specified IDs")
 		}
-	} else if len(itemTags) > 0 {
-		selectedIDs = r.FilterByTags(itemTags)
-		fmt.Printf("%sFiltering by tags: %s%s\n", colorYellow, strings.Join(itemTags, ", "), colorReset)
+		return selectedIDs, bulletVariants, nil
+	case itemQuery != "":
+		query, err := parseQuery(r, itemQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+		selectedIDs := r.FilterByQuery(query)
+		fmt.Fprintf(progress, "%sFiltering by query: %s%s\n", colorYellow, itemQuery, colorReset)
 		if len(selectedIDs) == 0 {
-			return fmt.Errorf("no items found matching the specified tags")
+			return nil, nil, fmt.Errorf("no items match the query")
 		}
-	} else {
-		selectedIDs = make(map[string]bool) // Empty map means include all
-		fmt.Printf("%sIncluding all items%s\n", colorYellow, colorReset)
+		return selectedIDs, bulletVariants, nil
+	case len(itemTags) > 0:
+		selectedIDs := r.FilterByTags(itemTags)
+		fmt.Fprintf(progress, "%sFiltering by tags: %s%s\n", colorYellow, strings.Join(itemTags, ", "), colorReset)
+		if len(selectedIDs) == 0 {
+			return nil, nil, fmt.Errorf("no items found matching the specified tags")
+		}
+		return selectedIDs, bulletVariants, nil
+	case profile != nil:
+		selectedIDs, bulletVariants := profile.Selection(r)
+		if len(selectedIDs) == 0 {
+			fmt.Fprintf(progress, "%sIncluding all items%s\n", colorYellow, colorReset)
+		}
+		return selectedIDs, bulletVariants, nil
 	}
+	fmt.Fprintf(progress, "%sIncluding all items%s\n", colorYellow, colorReset)
+	return make(map[string]bool), bulletVariants, nil // Empty map means include all
+}
 
-	// Generate LaTeX
-	fmt.Printf("%sGenerating LaTeX...%s\n", colorCyan, colorReset)
-	latexContent, err := generator.GenerateLatex(r, selectedIDs)
+// loadOrder loads order.yaml, or the file given by --order, with the
+// profile's ordering applied on top
+func loadOrder(r *resume.Resume, profile *resume.Profile) (*resume.SectionOrder, string, error) {
+	orderPath := orderFile
+	if orderPath == "" {
+		orderPath = resume.OrderPath(resumePath)
+	}
+	order, err := resume.LoadOrder(orderPath, r)
 	if err != nil {
-		return fmt.Errorf("failed to generate LaTeX: %w", err)
+		return nil, "", fmt.Errorf("failed to load order: %w", err)
+	}
+	if profile != nil {
+		order = profile.ApplyOrder(order)
 	}
+	return order, orderPath, nil
+}
 
-	// Write LaTeX to temporary file
-	texFile := strings.TrimSuffix(outputFile, ".pdf") + ".tex"
-	if err := os.WriteFile(texFile, []byte(latexContent), 0644); err != nil {
-		return fmt.Errorf("failed to write LaTeX file: %w", err)
+func runExport(cmd *cobra.Command, args []string) error {
+	if exportFormat != jsonresume.Format {
+		return fmt.Errorf("unknown format %q (available: %s)", exportFormat, jsonresume.Format)
+	}
+	// Progress goes to stderr when the export itself goes to stdout
+	progress := io.Writer(os.Stdout)
+	if exportOutput == "" {
+		progress = os.Stderr
 	}
-	fmt.Printf("%sWrote LaTeX to: %s%s\n", colorGreen, texFile, colorReset)
 
-	// Find xelatex
-	xelatexPath, err := generator.FindXelatex()
+	r, err := resume.LoadResume(resumePath)
+	if err != nil {
+		return fmt.Errorf("failed to load resume: %w", err)
+	}
+	profile, err := loadProfile(r, progress)
 	if err != nil {
-		return fmt.Errorf("xelatex not found in PATH. Please install a TeX distribution (MacTeX, TeX Live, or MiKTeX)")
+		return err
 	}
 
-	// Compile to PDF
-	fmt.Printf("%sCompiling PDF with xelatex...%s\n", colorCyan, colorReset)
-	if err := compilePDF(texFile, xelatexPath); err != nil {
-		return fmt.Errorf("failed to compile PDF: %w", err)
+	selecting := len(itemIDs) > 0 || len(itemTags) > 0 || itemQuery != "" || profile != nil
+	if selecting || exportOrdered || len(sectionNames) > 0 {
+		var selectedIDs map[string]bool
+		if selecting {
+			if selectedIDs, _, err = resolveSelection(r, profile, progress); err != nil {
+				return err
+			}
+		}
+		var order *resume.SectionOrder
+		if exportOrdered || profile != nil {
+			if order, _, err = loadOrder(r, profile); err != nil {
+				return err
+			}
+		}
+		sequence := sectionNames
+		if len(sequence) == 0 && exportOrdered {
+			sequence = order.Sections
+		}
+		if err := r.ValidateSections(sequence); err != nil {
+			return err
+		}
+		r = withSections(r.Select(selectedIDs, order), sequence)
 	}
 
-	// Clean up intermediate files
-	cleanupFiles(texFile)
+	data, err := jsonresume.Export(r)
+	if err != nil {
+		return fmt.Errorf("failed to export resume: %w", err)
+	}
+	if exportOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	fmt.Printf("%s✓ Exported %s to: %s%s\n", colorGreen, exportFormat, exportOutput, colorReset)
+	return nil
+}
 
-	fmt.Printf("%s✓ Successfully generated: %s%s\n", colorGreen, outputFile, colorReset)
+// withSections returns r with only the sections in sequence, and custom
+// sections in sequence order. An empty sequence keeps every section.
+func withSections(r *resume.Resume, sequence []string) *resume.Resume {
+	if len(sequence) == 0 {
+		return r
+	}
+	shown := make(map[string]bool, len(sequence))
+	for _, name := range sequence {
+		shown[name] = true
+	}
+	out := *r
+	if !shown[resume.SectionSummary] {
+		out.Summary = ""
+	}
+	if !shown[resume.SectionEducation] {
+		out.Education = nil
+	}
+	if !shown[resume.SectionSkills] {
+		out.Skills = nil
+	}
+	if !shown[resume.SectionExperience] {
+		out.Experience = nil
+	}
+	if !shown[resume.SectionProjects] {
+		out.Projects = nil
+	}
+	if !shown[resume.SectionLeadership] {
+		out.Leadership = nil
+	}
+	out.Sections = nil
+	for _, name := range sequence {
+		if section := r.CustomSection(name); section != nil {
+			out.Sections = append(out.Sections, *section)
+		}
+	}
+	return &out
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if importFrom != jsonresume.Format {
+		return fmt.Errorf("unknown format %q (available: %s)", importFrom, jsonresume.Format)
+	}
+	if _, err := os.Stat(resumePath); err == nil && !importForce {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", resumePath)
+	}
+
+	input, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	taxonomy, err := resume.LoadTaxonomy(resume.TaxonomyPath(resumePath))
+	if err != nil {
+		return fmt.Errorf("failed to load tags: %w", err)
+	}
+	r, err := jsonresume.Import(input, taxonomy)
+	if err != nil {
+		return err
+	}
+
+	data, err := resume.MarshalResume(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode resume: %w", err)
+	}
+	doc, err := resume.ParseDocument(data)
+	if err != nil {
+		return fmt.Errorf("imported resume does not parse: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+	if err := resume.WriteFileAtomic(resumePath, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", resumePath, err)
+	}
+	fmt.Printf("%s✓ Imported %s into: %s%s\n", colorGreen, args[0], resumePath, colorReset)
 	return nil
 }
 
@@ -278,6 +725,21 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	items := r.GetAllIDs()
+	if itemQuery != "" {
+		query, err := parseQuery(r, itemQuery)
+		if err != nil {
+			return err
+		}
+		matched := r.FilterByQuery(query)
+		var filtered []resume.ItemWithID
+		for _, item := range items {
+			if matched[item.ID] {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+		fmt.Printf("%s%d item(s) match: %s%s\n", colorYellow, len(items), itemQuery, colorReset)
+	}
 
 	// Group by section
 	sections := make(map[string][]resume.ItemWithID)
@@ -285,8 +747,12 @@ func runList(cmd *cobra.Command, args []string) error {
 		sections[item.Section] = append(sections[item.Section], item)
 	}
 
-	// Display in order: Experience, Projects, Leadership
-	sectionOrder := []string{"Experience", "Projects", "Leadership"}
+	// Display in order: Experience, Projects, Leadership, Education, Skills,
+	// then custom sections
+	sectionOrder := []string{"Experience", "Projects", "Leadership", "Education", "Skills"}
+	for _, section := range r.Sections {
+		sectionOrder = append(sectionOrder, section.Title)
+	}
 
 	for _, section := range sectionOrder {
 		items, ok := sections[section]
@@ -321,49 +787,267 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runServe(cmd *cobra.Command, args []string) error {
-	// Validate resume file exists
-	if _, err := os.Stat(resumePath); os.IsNotExist(err) {
-		return fmt.Errorf("resume file not found: %s", resumePath)
+func runValidate(cmd *cobra.Command, args []string) error {
+	orderPath := orderFile
+	if orderPath == "" {
+		orderPath = resume.OrderPath(resumePath)
 	}
 
-	fmt.Printf("%sStarting server with resume: %s%s\n", colorCyan, resumePath, colorReset)
-	fmt.Printf("%sServer will be available at: %shttp://localhost:%d%s\n", colorGreen, colorWhite, serverPort, colorReset)
+	problems, err := resume.Validate(resumePath, orderPath)
+	if err != nil {
+		return fmt.Errorf("failed to validate resume: %w", err)
+	}
 
-	return server.Start(resumePath, serverPort)
+	if len(problems) == 0 {
+		fmt.Printf("%s✓ %s is valid%s\n", colorGreen, resumePath, colorReset)
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%s%s%s\n", colorRed, problem, colorReset)
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("found %d problem(s)", len(problems))
 }
 
-func compilePDF(texFile, xelatexPath string) error {
-	// Get the directory containing the tex file for output
-	outputDir := filepath.Dir(texFile)
-	if outputDir == "" || outputDir == "." {
-		outputDir, _ = os.Getwd()
+func runTags(cmd *cobra.Command, args []string) error {
+	r, err := resume.LoadResume(resumePath)
+	if err != nil {
+		return fmt.Errorf("failed to load resume: %w", err)
+	}
+
+	usage := r.TagUsage()
+	tags := make([]string, 0, len(usage))
+	for tag := range usage {
+		tags = append(tags, tag)
 	}
-	// Convert to absolute path if needed
-	absTexFile, err := filepath.Abs(texFile)
+	sort.Slice(tags, func(i, j int) bool {
+		if usage[tags[i]] != usage[tags[j]] {
+			return usage[tags[i]] > usage[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+
+	fmt.Printf("\n%s=== Tags ===%s\n\n", colorGreen, colorReset)
+	for _, tag := range tags {
+		fmt.Printf("%4d  %s%s%s", usage[tag], colorBlue, tag, colorReset)
+		if def := r.Taxonomy.Lookup(tag); def != nil {
+			if len(def.Aliases) > 0 {
+				fmt.Printf(" %s(aliases: %s)%s", colorPurple, strings.Join(def.Aliases, ", "), colorReset)
+			}
+			if len(def.Implies) > 0 {
+				fmt.Printf(" %s→ %s%s", colorPurple, strings.Join(def.Implies, ", "), colorReset)
+			}
+		}
+		fmt.Println()
+	}
+
+	if r.Taxonomy != nil {
+		var unknown []string
+		for _, tag := range tags {
+			if !r.Taxonomy.Known(tag) {
+				unknown = append(unknown, tag)
+			}
+		}
+		sort.Strings(unknown)
+		if len(unknown) > 0 {
+			fmt.Printf("\n%sNot in %s:%s %s\n", colorYellow, resume.TaxonomyPath(resumePath), colorReset, strings.Join(unknown, ", "))
+		}
+	}
+
+	duplicates := resume.NearDuplicateTags(tags)
+	if len(duplicates) > 0 {
+		fmt.Printf("\n%sPossible duplicates (add an alias to tags.yaml to merge them):%s\n", colorYellow, colorReset)
+		for _, pair := range duplicates {
+			fmt.Printf("  %s (%d) / %s (%d)\n", pair[0], usage[pair[0]], pair[1], usage[pair[1]])
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runProfileSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := resume.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	r, err := resume.LoadResume(resumePath)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+		return fmt.Errorf("failed to load resume: %w", err)
 	}
-	absOutputDir := filepath.Dir(absTexFile)
 
-	// Run xelatex twice for proper formatting
-	for i := 0; i < 2; i++ {
-		cmd := exec.Command(xelatexPath, "-interaction=nonstopmode", "-output-directory="+absOutputDir, absTexFile)
-		output, err := cmd.CombinedOutput()
+	profile := &resume.Profile{
+		Description: profileDescription,
+		IDs:         itemIDs,
+		Tags:        itemTags,
+		Query:       itemQuery,
+		Skills:      profileSkills,
+		Template:    profileTemplate,
+		Variant:     variant,
+	}
+	if len(sectionNames) > 0 {
+		profile.Order = &resume.PartialSectionOrder{Sections: &sectionNames}
+	}
+	if err := profile.Validate(r); err != nil {
+		return err
+	}
+	if profile.Template != "" {
+		registry, err := loadTemplates()
 		if err != nil {
-			return fmt.Errorf("xelatex failed: %w\nOutput: %s", err, string(output))
+			return err
+		}
+		if _, err := registry.Get(profile.Template); err != nil {
+			return err
+		}
+	}
+
+	profilesPath := resume.ProfilesPath(resumePath)
+	profiles, err := resume.LoadProfiles(profilesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load profiles: %w", err)
+	}
+	profiles[name] = profile
+	if err := resume.SaveProfiles(profilesPath, profiles); err != nil {
+		return fmt.Errorf("failed to save profiles: %w", err)
+	}
+
+	fmt.Printf("%s✓ Saved profile %s to %s%s\n", colorGreen, name, profilesPath, colorReset)
+	return nil
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	profiles, err := resume.LoadProfiles(resume.ProfilesPath(resumePath))
+	if err != nil {
+		return fmt.Errorf("failed to load profiles: %w", err)
+	}
+	if len(profiles) == 0 {
+		fmt.Println("No profiles saved. Create one with: resume-cli profile save <name> --tags ...")
+		return nil
+	}
+
+	fmt.Printf("\n%s=== Profiles ===%s\n\n", colorGreen, colorReset)
+	for _, name := range profiles.Names() {
+		profile := profiles[name]
+		fmt.Printf("%s%s%s", colorBlue, name, colorReset)
+		if profile.Description != "" {
+			fmt.Printf(" - %s", profile.Description)
+		}
+		fmt.Println()
+		if len(profile.Tags) > 0 {
+			fmt.Printf("  %sTags:%s %s\n", colorPurple, colorReset, strings.Join(profile.Tags, ", "))
+		}
+		if profile.Query != "" {
+			fmt.Printf("  %sQuery:%s %s\n", colorPurple, colorReset, profile.Query)
+		}
+		if len(profile.IDs) > 0 {
+			fmt.Printf("  %sIDs:%s %d selected\n", colorPurple, colorReset, len(profile.IDs))
+		}
+		if profile.Template != "" {
+			fmt.Printf("  %sTemplate:%s %s\n", colorPurple, colorReset, profile.Template)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runProfileShow(cmd *cobra.Command, args []string) error {
+	profiles, err := resume.LoadProfiles(resume.ProfilesPath(resumePath))
+	if err != nil {
+		return fmt.Errorf("failed to load profiles: %w", err)
+	}
+	profile, err := profiles.Get(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) error {
+	profilesPath := resume.ProfilesPath(resumePath)
+	profiles, err := resume.LoadProfiles(profilesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load profiles: %w", err)
+	}
+	if _, err := profiles.Get(args[0]); err != nil {
+		return err
+	}
+	delete(profiles, args[0])
+	if err := resume.SaveProfiles(profilesPath, profiles); err != nil {
+		return fmt.Errorf("failed to save profiles: %w", err)
+	}
+
+	fmt.Printf("%s✓ Deleted profile %s%s\n", colorGreen, args[0], colorReset)
+	return nil
+}
+
+func runTemplates(cmd *cobra.Command, args []string) error {
+	registry, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s=== Templates ===%s\n\n", colorGreen, colorReset)
+	for _, t := range registry.List() {
+		source := "user"
+		if t.Builtin {
+			source = "built-in"
+		}
+		name := t.Name
+		if name == generator.DefaultTemplate {
+			name += " (default)"
+		}
+		fmt.Printf("  %s%s%s %s[%s]%s\n", colorBlue, name, colorReset, colorPurple, source, colorReset)
+		if t.Description != "" {
+			fmt.Printf("    %s\n", t.Description)
 		}
 	}
+	fmt.Println()
+
 	return nil
 }
 
-func cleanupFiles(texFile string) {
-	base := strings.TrimSuffix(texFile, ".tex")
-	extensions := []string{".aux", ".log", ".out"}
+// loadTemplates builds the template registry from the built-ins and the user template directory
+func loadTemplates() (*generator.Registry, error) {
+	dir := templateDir
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(resumePath), "templates")
+	}
 
-	for _, ext := range extensions {
-		os.Remove(base + ext)
+	registry := generator.NewRegistry()
+	if err := registry.LoadDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+	return registry, nil
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// Validate resume file exists
+	if _, err := os.Stat(resumePath); os.IsNotExist(err) {
+		return fmt.Errorf("resume file not found: %s", resumePath)
+	}
+
+	fmt.Printf("%sStarting server with resume: %s%s\n", colorCyan, resumePath, colorReset)
+	fmt.Printf("%sServer will be available at: %shttp://localhost:%d%s\n", colorGreen, colorWhite, serverPort, colorReset)
+
+	return server.Start(resumePath, serverPort)
+}
+
+// parseQuery parses a tag query and checks its section names against r
+func parseQuery(r *resume.Resume, s string) (*resume.Query, error) {
+	query, err := resume.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.ValidateQuery(query); err != nil {
+		return nil, err
 	}
+	return query, nil
 }
 
 func getScoreColor(score float64) string {
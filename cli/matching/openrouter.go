@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/evanqhuang/resume-cli/resume"
@@ -145,21 +146,23 @@ func buildMatchingPrompt(r *resume.Resume, jobDescription string) string {
 
 	// Experience bullets
 	for _, exp := range r.Experience {
-		for _, bullet := range exp.Bullets {
-			fmt.Fprintf(&prompt, "ID: %s\nText: %s\nTags: %v\n\n", bullet.ID, bullet.Text, bullet.Tags)
+		for _, role := range exp.Roles() {
+			for _, bullet := range role.Bullets {
+				writeBulletItem(&prompt, bullet, r.Taxonomy)
+			}
 		}
 	}
 
 	// Project bullets
 	for _, proj := range r.Projects {
 		for _, bullet := range proj.Bullets {
-			fmt.Fprintf(&prompt, "ID: %s\nText: %s\nTags: %v\n\n", bullet.ID, bullet.Text, bullet.Tags)
+			writeBulletItem(&prompt, bullet, r.Taxonomy)
 		}
 	}
 
 	// Leadership entries
 	for _, lead := range r.Leadership {
-		fmt.Fprintf(&prompt, "ID: %s\nText: %s\nTags: %v\n\n", lead.ID, lead.Text, lead.Tags)
+		fmt.Fprintf(&prompt, "ID: %s\nText: %s\nTags: %v\n\n", lead.ID, lead.Text, r.Taxonomy.Expand(lead.Tags))
 	}
 
 	prompt.WriteString("\nReturn your response as a JSON object with this exact format:\n")
@@ -268,25 +271,40 @@ func AnalyzeJobForAPI(r *resume.Resume, jobTitle, company, jobDescription string
 	return parseAnalysisResponse(apiResp.Choices[0].Message.Content, allItemIDs)
 }
 
+// writeBulletItem writes one scorable bullet. Variants are listed under the
+// same ID so the bullet is scored once, as a whole. Tags are written in
+// canonical form with implied tags added.
+func writeBulletItem(prompt *bytes.Buffer, bullet resume.Bullet, tax *resume.Taxonomy) {
+	fmt.Fprintf(prompt, "ID: %s\nText: %s\n", bullet.ID, bullet.Text)
+	names := make([]string, 0, len(bullet.Variants))
+	for name := range bullet.Variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(prompt, "Alternate wording (%s): %s\n", name, bullet.Variants[name])
+	}
+	fmt.Fprintf(prompt, "Tags: %v\n\n", tax.Expand(bullet.Tags))
+}
+
 func collectAllItemIDs(r *resume.Resume) []string {
 	var ids []string
 
 	// Skills
-	for _, skill := range r.Skills.Languages {
-		ids = append(ids, "skill-"+strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-")))
-	}
-	for _, skill := range r.Skills.Frameworks {
-		ids = append(ids, "skill-"+strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-")))
-	}
-	for _, skill := range r.Skills.Cloud {
-		ids = append(ids, "skill-"+strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-")))
+	for _, skill := range r.Skills.All() {
+		ids = append(ids, skill.SkillID())
 	}
 
 	// Experience
 	for _, exp := range r.Experience {
 		ids = append(ids, exp.ID)
-		for _, bullet := range exp.Bullets {
-			ids = append(ids, bullet.ID)
+		for _, role := range exp.Roles() {
+			if role.ID != exp.ID {
+				ids = append(ids, role.ID)
+			}
+			for _, bullet := range role.Bullets {
+				ids = append(ids, bullet.ID)
+			}
 		}
 	}
 
@@ -303,6 +321,21 @@ func collectAllItemIDs(r *resume.Resume) []string {
 		ids = append(ids, lead.ID)
 	}
 
+	// Education
+	for _, edu := range r.Education {
+		ids = append(ids, edu.EntryID())
+		for _, course := range edu.Coursework {
+			ids = append(ids, course.ID)
+		}
+	}
+
+	// Custom sections
+	for _, section := range r.Sections {
+		for _, item := range section.Items {
+			ids = append(ids, item.ID)
+		}
+	}
+
 	return ids
 }
 
@@ -318,44 +351,42 @@ func buildAPIAnalysisPrompt(r *resume.Resume, jobTitle, company, jobDescription
 
 	// Skills
 	prompt.WriteString("SKILLS:\n")
-	for _, skill := range r.Skills.Languages {
-		skillID := "skill-" + strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-"))
-		tags := strings.Join(skill.Tags, ", ")
-		if len(skill.Tags) > 5 {
-			tags = strings.Join(skill.Tags[:5], ", ")
-		}
-		fmt.Fprintf(&prompt, "  %s: %s (%s)\n", skillID, skill.Name, tags)
-	}
-	for _, skill := range r.Skills.Frameworks {
-		skillID := "skill-" + strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-"))
-		tags := strings.Join(skill.Tags, ", ")
-		if len(skill.Tags) > 5 {
-			tags = strings.Join(skill.Tags[:5], ", ")
-		}
-		fmt.Fprintf(&prompt, "  %s: %s (%s)\n", skillID, skill.Name, tags)
-	}
-	for _, skill := range r.Skills.Cloud {
-		skillID := "skill-" + strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-"))
-		tags := strings.Join(skill.Tags, ", ")
-		if len(skill.Tags) > 5 {
-			tags = strings.Join(skill.Tags[:5], ", ")
+	for _, category := range r.Skills {
+		fmt.Fprintf(&prompt, "  %s:\n", category.DisplayLabel())
+		for _, skill := range category.Items {
+			skillTags := r.Taxonomy.Expand(skill.Tags)
+			tags := strings.Join(skillTags, ", ")
+			if len(skillTags) > 5 {
+				tags = strings.Join(skillTags[:5], ", ")
+			}
+			fmt.Fprintf(&prompt, "    %s: %s (%s)\n", skill.SkillID(), skill.Name, tags)
 		}
-		fmt.Fprintf(&prompt, "  %s: %s (%s)\n", skillID, skill.Name, tags)
 	}
 
 	// Experience
 	prompt.WriteString("\nEXPERIENCE:\n")
 	for _, exp := range r.Experience {
-		fmt.Fprintf(&prompt, "  %s: %s at %s\n", exp.ID, exp.Title, exp.Company)
-		for i, bullet := range exp.Bullets {
-			if i >= 3 {
-				break
+		if len(exp.Positions) == 0 {
+			fmt.Fprintf(&prompt, "  %s: %s at %s\n", exp.ID, exp.Title, exp.Company)
+		} else {
+			fmt.Fprintf(&prompt, "  %s: %s\n", exp.ID, exp.Company)
+		}
+		for _, role := range exp.Roles() {
+			indent := "    "
+			if len(exp.Positions) > 0 {
+				fmt.Fprintf(&prompt, "    %s: %s\n", role.ID, role.Title)
+				indent = "      "
 			}
-			text := bullet.Text
-			if len(text) > 100 {
-				text = text[:100] + "..."
+			for i, bullet := range role.Bullets {
+				if i >= 3 {
+					break
+				}
+				text := bullet.Text
+				if len(text) > 100 {
+					text = text[:100] + "..."
+				}
+				fmt.Fprintf(&prompt, "%s%s: %s\n", indent, bullet.ID, text)
 			}
-			fmt.Fprintf(&prompt, "    %s: %s\n", bullet.ID, text)
 		}
 	}
 
@@ -375,6 +406,23 @@ func buildAPIAnalysisPrompt(r *resume.Resume, jobTitle, company, jobDescription
 		}
 	}
 
+	// Education
+	prompt.WriteString("\nEDUCATION:\n")
+	for _, edu := range r.Education {
+		fmt.Fprintf(&prompt, "  %s: %s, %s\n", edu.EntryID(), edu.Degree, edu.Institution)
+		for _, course := range edu.Coursework {
+			fmt.Fprintf(&prompt, "    %s: %s\n", course.ID, course.Text)
+		}
+	}
+
+	// Custom sections
+	for _, section := range r.Sections {
+		fmt.Fprintf(&prompt, "\n%s:\n", strings.ToUpper(section.Title))
+		for _, item := range section.Items {
+			fmt.Fprintf(&prompt, "  %s: %s\n", item.ID, item.DisplayText())
+		}
+	}
+
 	prompt.WriteString("\nAvailable Item IDs:\n")
 	prompt.WriteString(strings.Join(allItemIDs, ", "))
 
@@ -0,0 +1,260 @@
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Date is a resume date such as "Aug 2023", "2023-08", "2020" or "Present".
+// The original text is kept so output can reproduce it unchanged.
+type Date struct {
+	Raw  string
+	Year int
+	// Month is zero when only the year is known
+	Month   time.Month
+	Present bool
+}
+
+// presentWords are the spellings accepted for an ongoing end date
+var presentWords = map[string]bool{
+	"present": true,
+	"current": true,
+	"now":     true,
+	"ongoing": true,
+}
+
+// monthNames maps lowercase month names and abbreviations to months
+var monthNames = map[string]time.Month{}
+
+// seasonMonths maps seasons to the month they conventionally start in
+var seasonMonths = map[string]time.Month{
+	"spring": time.March,
+	"summer": time.June,
+	"fall":   time.September,
+	"autumn": time.September,
+	"winter": time.December,
+}
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		monthNames[name] = m
+		monthNames[name[:3]] = m
+	}
+	monthNames["sept"] = time.September
+}
+
+// ParseDate parses the date formats used on resumes: "2020", "2020-05",
+// "05/2020", "May 2020", "May. 2020", "Summer 2020" and "Present"
+func ParseDate(s string) (Date, error) {
+	raw := strings.TrimSpace(s)
+	d := Date{Raw: raw}
+	if raw == "" {
+		return d, nil
+	}
+	lower := strings.ToLower(raw)
+	if presentWords[lower] {
+		d.Present = true
+		return d, nil
+	}
+
+	invalid := fmt.Errorf("invalid date %q (expected e.g. \"Aug 2023\", \"2023-08\", \"2023\" or \"Present\")", raw)
+
+	// Year only
+	if year, ok := parseYear(lower); ok {
+		d.Year = year
+		return d, nil
+	}
+
+	// 2020-05 (a trailing day is ignored) or 05/2020
+	if y, m, ok := strings.Cut(lower, "-"); ok {
+		m, _, _ = strings.Cut(m, "-")
+		return d.withNumeric(y, m, invalid)
+	}
+	if m, y, ok := strings.Cut(lower, "/"); ok {
+		return d.withNumeric(y, m, invalid)
+	}
+
+	// May 2020, May. 2020, Summer 2020
+	fields := strings.Fields(strings.ReplaceAll(lower, ",", " "))
+	if len(fields) != 2 {
+		return d, invalid
+	}
+	year, ok := parseYear(fields[1])
+	if !ok {
+		return d, invalid
+	}
+	name := strings.TrimSuffix(fields[0], ".")
+	month, ok := monthNames[name]
+	if !ok {
+		month, ok = seasonMonths[name]
+	}
+	if !ok {
+		return d, invalid
+	}
+	d.Year, d.Month = year, month
+	return d, nil
+}
+
+func (d Date) withNumeric(year, month string, invalid error) (Date, error) {
+	y, ok := parseYear(year)
+	if !ok {
+		return d, invalid
+	}
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return d, invalid
+	}
+	d.Year, d.Month = y, time.Month(m)
+	return d, nil
+}
+
+func parseYear(s string) (int, bool) {
+	if len(s) != 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(s)
+	if err != nil || year < 1900 || year > 2200 {
+		return 0, false
+	}
+	return year, true
+}
+
+// IsZero reports whether the date was left empty
+func (d Date) IsZero() bool {
+	return d.Raw == "" && d.Year == 0 && !d.Present
+}
+
+// Parsed reports whether the date was understood, as a calendar date or as
+// present. It is false for empty dates and for text ParseDate rejects.
+func (d Date) Parsed() bool {
+	return d.Year != 0 || d.Present
+}
+
+// String returns the date as written in the resume
+func (d Date) String() string {
+	return d.Raw
+}
+
+// Time returns the first day of the date's month (January for year-only
+// dates). Present dates resolve to now.
+func (d Date) Time(now time.Time) time.Time {
+	if d.Present {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	month := d.Month
+	if month == 0 {
+		month = time.January
+	}
+	return time.Date(d.Year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Format renders the date with a Go time layout such as "Jan 2006" or
+// "01/2006". An empty layout or an unparsed date returns the date as
+// written; year-only dates always render as the year and present dates as
+// "Present".
+func (d Date) Format(layout string) string {
+	switch {
+	case layout == "" || !d.Parsed():
+		return d.Raw
+	case d.Present:
+		return "Present"
+	case d.Month == 0:
+		return strconv.Itoa(d.Year)
+	}
+	return d.Time(time.Time{}).Format(layout)
+}
+
+// Before reports whether d is earlier than other; present sorts last and
+// empty or unparsed dates sort first
+func (d Date) Before(other Date, now time.Time) bool {
+	if !d.Parsed() || !other.Parsed() {
+		return !d.Parsed() && other.Parsed()
+	}
+	return d.Time(now).Before(other.Time(now))
+}
+
+// MonthsBetween returns the number of months from start to end, counting
+// both the first and last month. Year-only end dates count through December.
+// It returns 0 if either date is empty or unparsed, or end precedes start.
+func MonthsBetween(start, end Date, now time.Time) int {
+	if !start.Parsed() || !end.Parsed() {
+		return 0
+	}
+	from, to := start.Time(now), end.Time(now)
+	if !end.Present && end.Month == 0 {
+		to = time.Date(end.Year, time.December, 1, 0, 0, 0, 0, time.UTC)
+	}
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// FormatMonths renders a month count as "2 yrs 3 mos", "1 yr" or "8 mos"
+func FormatMonths(months int) string {
+	if months <= 0 {
+		return ""
+	}
+	plural := func(n int, one, many string) string {
+		if n == 1 {
+			return "1 " + one
+		}
+		return strconv.Itoa(n) + " " + many
+	}
+	years, rest := months/12, months%12
+	switch {
+	case years == 0:
+		return plural(rest, "mo", "mos")
+	case rest == 0:
+		return plural(years, "yr", "yrs")
+	}
+	return plural(years, "yr", "yrs") + " " + plural(rest, "mo", "mos")
+}
+
+// UnmarshalYAML parses a scalar date. Text that is not a recognized date is
+// kept in Raw with nothing parsed, so the resume still loads; Validate
+// reports it.
+func (d *Date) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: date must be a single value", node.Line)
+	}
+	parsed, err := ParseDate(node.Value)
+	if err != nil {
+		parsed = Date{Raw: parsed.Raw}
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalYAML writes the date as originally written
+func (d Date) MarshalYAML() (any, error) {
+	return d.Raw, nil
+}
+
+// MarshalJSON writes the date as originally written
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Raw)
+}
+
+// UnmarshalJSON parses a date string. Like UnmarshalYAML, text that is not
+// a recognized date is kept in Raw with nothing parsed; callers that need a
+// valid date check it with ParseDate.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		parsed = Date{Raw: parsed.Raw}
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,153 @@
+package resume
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		year    int
+		month   time.Month
+		present bool
+	}{
+		{"2020", 2020, 0, false},
+		{"2020-05", 2020, time.May, false},
+		{"2020-05-14", 2020, time.May, false},
+		{"5/2020", 2020, time.May, false},
+		{"Aug 2023", 2023, time.August, false},
+		{"August 2023", 2023, time.August, false},
+		{"Sept. 2019", 2019, time.September, false},
+		{"Summer 2021", 2021, time.June, false},
+		{"Present", 0, 0, true},
+		{"current", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDate(tt.input)
+		if err != nil {
+			t.Errorf("ParseDate(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if d.Year != tt.year || d.Month != tt.month || d.Present != tt.present {
+			t.Errorf("ParseDate(%q) = %+v", tt.input, d)
+		}
+		if d.String() != tt.input {
+			t.Errorf("ParseDate(%q).String() = %q", tt.input, d.String())
+		}
+	}
+
+	for _, input := range []string{"Augst 2023", "20", "2020-13", "next year"} {
+		if _, err := ParseDate(input); err == nil {
+			t.Errorf("ParseDate(%q) should fail", input)
+		}
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	tests := []struct {
+		input, layout, expected string
+	}{
+		{"August 2023", "", "August 2023"},
+		{"August 2023", "Jan 2006", "Aug 2023"},
+		{"2023-08", "January 2006", "August 2023"},
+		{"2020", "Jan 2006", "2020"},
+		{"Current", "Jan 2006", "Present"},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDate(tt.input)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) failed: %v", tt.input, err)
+		}
+		if got := d.Format(tt.layout); got != tt.expected {
+			t.Errorf("%q.Format(%q) = %q, want %q", tt.input, tt.layout, got, tt.expected)
+		}
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start, end string
+		months     int
+		formatted  string
+	}{
+		{"Jan 2020", "Dec 2020", 12, "1 yr"},
+		{"Aug 2023", "Present", 32, "2 yrs 8 mos"},
+		{"June 2022", "August 2022", 3, "3 mos"},
+		{"2020", "2021", 24, "2 yrs"},
+		{"May 2022", "Jan 2022", 0, ""},
+	}
+
+	for _, tt := range tests {
+		start, _ := ParseDate(tt.start)
+		end, _ := ParseDate(tt.end)
+		months := MonthsBetween(start, end, now)
+		if months != tt.months {
+			t.Errorf("MonthsBetween(%q, %q) = %d, want %d", tt.start, tt.end, months, tt.months)
+		}
+		if got := FormatMonths(months); got != tt.formatted {
+			t.Errorf("FormatMonths(%d) = %q, want %q", months, got, tt.formatted)
+		}
+	}
+}
+
+func TestDateYAML(t *testing.T) {
+	var entry ExperienceEntry
+	if err := yaml.Unmarshal([]byte("start_date: 2020\nend_date: Present\n"), &entry); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if entry.StartDate.Year != 2020 || !entry.EndDate.Present {
+		t.Errorf("unexpected dates: %+v - %+v", entry.StartDate, entry.EndDate)
+	}
+
+	out, err := yaml.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var roundTrip ExperienceEntry
+	if err := yaml.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("round trip failed: %v", err)
+	}
+	if roundTrip.StartDate.Raw != "2020" || roundTrip.EndDate.Raw != "Present" {
+		t.Errorf("round trip changed dates: %+v", roundTrip)
+	}
+
+	// Unrecognized dates still load, keeping their text for Validate to report
+	if err := yaml.Unmarshal([]byte("start_date: Expected May 2025\n"), &entry); err != nil {
+		t.Fatalf("Unmarshal failed for an unrecognized date: %v", err)
+	}
+	if entry.StartDate.Raw != "Expected May 2025" || entry.StartDate.Parsed() {
+		t.Errorf("unrecognized date = %+v, want only Raw set", entry.StartDate)
+	}
+	if got := entry.StartDate.Format("Jan 2006"); got != "Expected May 2025" {
+		t.Errorf("Format of an unrecognized date = %q", got)
+	}
+	if got := MonthsBetween(entry.StartDate, Date{Present: true}, time.Now()); got != 0 {
+		t.Errorf("MonthsBetween with an unrecognized date = %d, want 0", got)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var dates struct {
+		Start Date `json:"start"`
+		End   Date `json:"end"`
+	}
+	if err := json.Unmarshal([]byte(`{"start": "Aug 2023", "end": "Expected May 2025"}`), &dates); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if dates.Start.Year != 2023 || dates.Start.Month != time.August {
+		t.Errorf("start = %+v", dates.Start)
+	}
+	if dates.End.Raw != "Expected May 2025" || dates.End.Parsed() {
+		t.Errorf("unrecognized date = %+v, want only Raw set", dates.End)
+	}
+	if err := json.Unmarshal([]byte(`{"start": 2023}`), &dates); err == nil {
+		t.Error("expected error for a date that is not a string")
+	}
+}
@@ -0,0 +1,901 @@
+package resume
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is resume.yaml held as a YAML node tree so it can be edited and
+// written back without losing comments, key order, block or flow style, or
+// the blank lines between entries. Items are addressed by the same IDs the
+// rest of the package uses.
+type Document struct {
+	path  string
+	root  yaml.Node
+	lines []string
+	// blockValues holds the parsed value of each block scalar, so unchanged
+	// ones can be written back with their original line breaks
+	blockValues map[*yaml.Node]string
+	checksum    string
+}
+
+// ErrUnknownID is matched (with errors.Is) by errors for IDs a document
+// does not contain
+var ErrUnknownID = errors.New("unknown ID")
+
+// unknownIDError keeps the message of an ID lookup failure, including any
+// suggestions, while matching ErrUnknownID
+type unknownIDError struct{ err error }
+
+func (e unknownIDError) Error() string        { return e.err.Error() }
+func (e unknownIDError) Is(target error) bool { return target == ErrUnknownID }
+
+// ErrInvalidEdit is matched (with errors.Is) by errors for edits that do not
+// fit the document, such as reusing an ID or setting a list to a string
+var ErrInvalidEdit = errors.New("invalid edit")
+
+// invalidEditError keeps the message of a rejected edit while matching
+// ErrInvalidEdit
+type invalidEditError struct{ err error }
+
+func (e invalidEditError) Error() string        { return e.err.Error() }
+func (e invalidEditError) Is(target error) bool { return target == ErrInvalidEdit }
+
+func invalidEdit(format string, args ...any) error {
+	return invalidEditError{fmt.Errorf(format, args...)}
+}
+
+// Checksum returns the hex SHA-256 of file contents, identifying the version
+// of resume.yaml an edit was made against
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// OpenDocument reads a resume file for editing
+func OpenDocument(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	d.path = path
+	return d, nil
+}
+
+// ParseDocument parses resume YAML for editing
+func ParseDocument(data []byte) (*Document, error) {
+	d := &Document{}
+	if err := d.load(data); err != nil {
+		return nil, err
+	}
+	if len(d.root.Content) == 0 {
+		d.root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if d.root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("resume must be a mapping")
+	}
+	return d, nil
+}
+
+// load parses data as the document's unedited state
+func (d *Document) load(data []byte) error {
+	d.root = yaml.Node{}
+	if err := yaml.Unmarshal(data, &d.root); err != nil {
+		return err
+	}
+	d.lines = strings.Split(string(data), "\n")
+	d.checksum = Checksum(data)
+	d.blockValues = make(map[*yaml.Node]string)
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			d.blockValues[node] = node.Value
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&d.root)
+	return nil
+}
+
+// Checksum returns the checksum of the document as last read or saved
+func (d *Document) Checksum() string {
+	return d.checksum
+}
+
+// Resume decodes the document in its current state
+func (d *Document) Resume() (*Resume, error) {
+	var r Resume
+	if err := d.root.Decode(&r); err != nil {
+		return nil, err
+	}
+	if err := r.validateSections(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Bytes encodes the document, restoring the blank lines the YAML encoder
+// drops. Items added by an edit get a blank line if their siblings have one.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&d.root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	var encoded yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &encoded); err != nil {
+		return nil, err
+	}
+	out := strings.Split(buf.String(), "\n")
+	blank := make(map[int]bool)
+	blocks := make(map[int]lineSpan)
+	d.markLines(&d.root, &encoded, out, blank, blocks)
+
+	var result []string
+	for i := 0; i < len(out); i++ {
+		if blank[i+1] {
+			result = append(result, "")
+		}
+		if span, ok := blocks[i+1]; ok {
+			result = append(result, d.lines[span.origStart-1:span.origEnd]...)
+			i = span.end - 1
+			continue
+		}
+		result = append(result, out[i])
+	}
+	return []byte(strings.Join(result, "\n")), nil
+}
+
+// lineSpan maps the content lines of a block scalar in the encoded output,
+// from its start line through end, to the lines it had in the original file
+type lineSpan struct {
+	end                int
+	origStart, origEnd int
+}
+
+// blockContent returns the 1-based range of content lines of a block scalar
+// whose indicator is on line, for a key indented by indent; ok is false if
+// the scalar has no content lines
+func blockContent(lines []string, line, indent int) (start, end int, ok bool) {
+	start, end = line+1, line
+	for i := line; i < len(lines); i++ {
+		text := lines[i]
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if len(text)-len(strings.TrimLeft(text, " ")) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return start, end, end >= start
+}
+
+// markLines walks the edited tree and its encoded form in step, recording
+// the encoded lines that should be preceded by a blank line and the unedited
+// block scalars whose original lines should be kept
+func (d *Document) markLines(edited, encoded *yaml.Node, out []string, blank map[int]bool, blocks map[int]lineSpan) {
+	if edited == nil || encoded == nil || len(edited.Content) != len(encoded.Content) {
+		return
+	}
+
+	// Children that start a line: mapping keys and sequence items
+	var starts []int
+	switch edited.Kind {
+	case yaml.DocumentNode:
+		d.markLines(edited.Content[0], encoded.Content[0], out, blank, blocks)
+		return
+	case yaml.MappingNode:
+		for i := 0; i < len(edited.Content); i += 2 {
+			starts = append(starts, i)
+			key, value := edited.Content[i], edited.Content[i+1]
+			if original, ok := d.blockValues[value]; !ok || original != value.Value || value.Line == 0 {
+				continue
+			}
+			encKey, encValue := encoded.Content[i], encoded.Content[i+1]
+			origStart, origEnd, ok := blockContent(d.lines, value.Line, key.Column-1)
+			start, end, encOK := blockContent(out, encValue.Line, encKey.Column-1)
+			if ok && encOK {
+				blocks[start] = lineSpan{end: end, origStart: origStart, origEnd: origEnd}
+			}
+		}
+	case yaml.SequenceNode:
+		if edited.Style&yaml.FlowStyle != 0 {
+			return
+		}
+		for i := range edited.Content {
+			starts = append(starts, i)
+		}
+	default:
+		return
+	}
+
+	// Children keep their own spacing while they follow the sibling they
+	// followed in the file. Moved and new children take the spacing of the
+	// original first child when first, and otherwise the majority spacing of
+	// the others.
+	first, known, spaced := -1, 0, 0
+	for _, i := range starts {
+		child := edited.Content[i]
+		if child.Line == 0 {
+			continue
+		}
+		if first < 0 || child.Line < edited.Content[first].Line {
+			first = i
+		}
+	}
+	for _, i := range starts {
+		if child := edited.Content[i]; child.Line > 0 && i != first {
+			known++
+			if blankBefore(child.Line, d.lines) {
+				spaced++
+			}
+		}
+	}
+	prevLine := 0
+	for n, i := range starts {
+		child := edited.Content[i]
+		if n == 0 && edited.Kind == yaml.MappingNode {
+			// The first key shares a line with whatever holds the mapping
+			prevLine = child.Line
+			continue
+		}
+		var want bool
+		switch {
+		case child.Line > 0 && (n == 0 && i == first || n > 0 && prevLine > 0 && d.adjacent(edited, starts, prevLine, child.Line)):
+			want = blankBefore(child.Line, d.lines)
+		case n == 0:
+			want = first >= 0 && blankBefore(edited.Content[first].Line, d.lines)
+		default:
+			want = known > 0 && spaced*2 >= known
+		}
+		if want && encoded.Content[i].Line > 1 {
+			blank[commentStart(encoded.Content[i].Line, out)] = true
+		}
+		prevLine = child.Line
+	}
+
+	for i := range edited.Content {
+		d.markLines(edited.Content[i], encoded.Content[i], out, blank, blocks)
+	}
+}
+
+// adjacent reports whether the children of node on lines prev and next
+// had no other child between them in the file
+func (d *Document) adjacent(node *yaml.Node, starts []int, prev, next int) bool {
+	if prev >= next {
+		return false
+	}
+	for _, i := range starts {
+		if line := node.Content[i].Line; line > prev && line < next {
+			return false
+		}
+	}
+	return true
+}
+
+// blankBefore reports whether the node starting on line (1-based), together
+// with any comment lines directly above it, is preceded by a blank line
+func blankBefore(line int, lines []string) bool {
+	start := commentStart(line, lines)
+	return start > 1 && strings.TrimSpace(lines[start-2]) == ""
+}
+
+// commentStart returns the first line of the run of comment lines directly
+// above line, or line itself
+func commentStart(line int, lines []string) int {
+	for line > 1 && line-2 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[line-2]), "#") {
+		line--
+	}
+	return line
+}
+
+// Validate checks that the edited document still decodes as a resume and
+// uses each ID once
+func (d *Document) Validate() error {
+	r, err := d.Resume()
+	if err != nil {
+		return fmt.Errorf("edited resume is invalid: %w", err)
+	}
+	if dups := r.Index().Duplicates(); len(dups) > 0 {
+		return fmt.Errorf("edited resume is invalid: duplicate ID %q", dups[0])
+	}
+	return nil
+}
+
+// Save validates the document and writes it back to the file it was opened
+// from, keeping the previous contents in a .bak file
+func (d *Document) Save() error {
+	if d.path == "" {
+		return fmt.Errorf("document has no file path")
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(d.path, data); err != nil {
+		return err
+	}
+	return d.load(data)
+}
+
+// WriteFileAtomic replaces path with data by writing a temporary file in
+// the same directory and renaming it over the original, so readers never
+// see a partial file. An existing file is first copied to path + ".bak".
+func WriteFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		previous, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path+".bak", previous, mode); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SetText replaces the text of a bullet, coursework, leadership or custom
+// section item
+func (d *Document) SetText(id, text string) error {
+	return d.SetField(id, "text", text)
+}
+
+// SetField sets a scalar field of the item with the given ID, adding the
+// field if it is missing. The value keeps the field's existing scalar style
+// where it can represent the new value.
+func (d *Document) SetField(id, key, value string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	if node := mappingValue(item.node, key); node != nil {
+		if node.Kind != yaml.ScalarNode {
+			return invalidEdit("%s: field %q is not a single value", id, key)
+		}
+		setScalar(node, value)
+		return nil
+	}
+	setMappingValue(item.node, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	return nil
+}
+
+// SetValue sets a scalar reached by mapping keys from the top of the
+// document, such as ("summary") or ("contact", "email"), creating missing
+// mappings along the way
+func (d *Document) SetValue(value string, keys ...string) error {
+	node := d.root.Content[0]
+	for i, key := range keys {
+		next := mappingValue(node, key)
+		last := i == len(keys)-1
+		if next == nil || isNull(next) {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if last {
+				next = &yaml.Node{Kind: yaml.ScalarNode}
+			}
+			setMappingValue(node, key, next)
+		}
+		if !last && next.Kind != yaml.MappingNode {
+			return invalidEdit("%s is not a mapping", strings.Join(keys[:i+1], "."))
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode {
+		return invalidEdit("%s is not a single value", strings.Join(keys, "."))
+	}
+	setScalar(node, value)
+	return nil
+}
+
+// setScalar replaces a scalar's value, switching to a literal block if the
+// new value spans lines and the old style cannot hold it
+func setScalar(node *yaml.Node, value string) {
+	node.Value = value
+	node.Tag = "!!str"
+	if strings.Contains(value, "\n") && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		node.Style = yaml.LiteralStyle
+	}
+}
+
+// SetTags replaces an item's tags, keeping the style of its tags list
+func (d *Document) SetTags(id string, tags []string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	seq := mappingValue(item.node, "tags")
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: d.tagStyle()}
+		setMappingValue(item.node, "tags", seq)
+	}
+	seq.Content = nil
+	for _, tag := range tags {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+	}
+	return nil
+}
+
+// SetVariants replaces a bullet's alternate wordings; an empty map removes
+// them
+func (d *Document) SetVariants(id string, variants map[string]string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	if len(variants) == 0 {
+		removeMappingKey(item.node, "variants")
+		return nil
+	}
+	var node yaml.Node
+	if err := node.Encode(variants); err != nil {
+		return err
+	}
+	setMappingValue(item.node, "variants", &node)
+	return nil
+}
+
+// InsertSkill appends a skill to the category with the given name or label,
+// creating the category if needed. Both the category list and the legacy
+// mapping form are handled.
+func (d *Document) InsertSkill(category string, item SkillItem) error {
+	root := d.root.Content[0]
+	skills := mappingValue(root, SectionSkills)
+	if skills == nil || isNull(skills) {
+		skills = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingValue(root, SectionSkills, skills)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(item); err != nil {
+		return err
+	}
+	d.styleNew(&node)
+
+	switch skills.Kind {
+	case yaml.MappingNode:
+		items := mappingValue(skills, category)
+		if items == nil || isNull(items) {
+			items = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			setMappingValue(skills, category, items)
+		}
+		items.Content = append(items.Content, &node)
+		return nil
+	case yaml.SequenceNode:
+		for _, cat := range skills.Content {
+			name, label := mappingValue(cat, "name"), mappingValue(cat, "label")
+			if (name != nil && name.Value == category) || (label != nil && label.Value == category) {
+				items := mappingValue(cat, "items")
+				if items == nil || isNull(items) {
+					items = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+					setMappingValue(cat, "items", items)
+				}
+				items.Content = append(items.Content, &node)
+				return nil
+			}
+		}
+		var cat yaml.Node
+		if err := cat.Encode(SkillCategory{Name: category}); err != nil {
+			return err
+		}
+		setMappingValue(&cat, "items", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{&node}})
+		skills.Content = append(skills.Content, &cat)
+		return nil
+	}
+	return invalidEdit("skills must be a list of categories or a mapping")
+}
+
+// AddTags adds tags to an item, skipping ones it already has
+func (d *Document) AddTags(id string, tags ...string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	seq := mappingValue(item.node, "tags")
+	if seq == nil || isNull(seq) {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: d.tagStyle()}
+		setMappingValue(item.node, "tags", seq)
+	}
+	if seq.Kind != yaml.SequenceNode {
+		return invalidEdit("%s: tags is not a list", id)
+	}
+	for _, tag := range tags {
+		found := false
+		for _, existing := range seq.Content {
+			if existing.Value == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+		}
+	}
+	return nil
+}
+
+// RemoveTags removes tags from an item; tags it does not have are ignored
+func (d *Document) RemoveTags(id string, tags ...string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	seq := mappingValue(item.node, "tags")
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return nil
+	}
+	remove := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		remove[tag] = true
+	}
+	kept := seq.Content[:0]
+	for _, node := range seq.Content {
+		if !remove[node.Value] {
+			kept = append(kept, node)
+		}
+	}
+	seq.Content = kept
+	return nil
+}
+
+// InsertItem inserts item into the list field of the entry parentID, or of
+// the top level when parentID is empty (e.g. "", "projects"). An index of
+// -1 or past the end appends. The item is encoded with its YAML tags, and a
+// new tags list follows the style of the existing ones.
+func (d *Document) InsertItem(parentID, field string, index int, item any) error {
+	parent := d.root.Content[0]
+	if parentID != "" {
+		ref, err := d.find(parentID)
+		if err != nil {
+			return err
+		}
+		parent = ref.node
+	}
+
+	var node yaml.Node
+	if err := node.Encode(item); err != nil {
+		return err
+	}
+	d.styleNew(&node)
+
+	seq := mappingValue(parent, field)
+	if seq == nil || isNull(seq) {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingValue(parent, field, seq)
+	}
+	if seq.Kind != yaml.SequenceNode {
+		return invalidEdit("%s is not a list", describeField(parentID, field))
+	}
+	if id := mappingValue(&node, "id"); id != nil && id.Value != "" {
+		if _, err := d.find(id.Value); err == nil {
+			return invalidEdit("ID %q is already used", id.Value)
+		}
+	}
+
+	if index < 0 || index > len(seq.Content) {
+		index = len(seq.Content)
+	}
+	seq.Content = append(seq.Content, nil)
+	copy(seq.Content[index+1:], seq.Content[index:])
+	seq.Content[index] = &node
+	return nil
+}
+
+// RemoveItem removes the item with the given ID from its list
+func (d *Document) RemoveItem(id string) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	if item.list == nil {
+		return invalidEdit("%s is not in a list", id)
+	}
+	item.list.Content = append(item.list.Content[:item.index], item.list.Content[item.index+1:]...)
+	return nil
+}
+
+// MoveItem moves the item with the given ID to index within its list
+func (d *Document) MoveItem(id string, index int) error {
+	item, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	if item.list == nil {
+		return invalidEdit("%s is not in a list", id)
+	}
+	content := item.list.Content
+	if index < 0 || index >= len(content) {
+		return invalidEdit("index %d out of range for %s (0-%d)", index, id, len(content)-1)
+	}
+	node := content[item.index]
+	content = append(content[:item.index], content[item.index+1:]...)
+	content = append(content[:index], append([]*yaml.Node{node}, content[index:]...)...)
+	item.list.Content = content
+	return nil
+}
+
+// ReorderItems reorders the list field of parentID (or of the top level)
+// so the given IDs come first, in order; other items keep their relative
+// order after them
+func (d *Document) ReorderItems(parentID, field string, ids []string) error {
+	parent := d.root.Content[0]
+	if parentID != "" {
+		ref, err := d.find(parentID)
+		if err != nil {
+			return err
+		}
+		parent = ref.node
+	}
+	seq := mappingValue(parent, field)
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return invalidEdit("%s is not a list", describeField(parentID, field))
+	}
+
+	byID := make(map[string]*yaml.Node)
+	for _, node := range seq.Content {
+		if id := mappingValue(node, "id"); id != nil {
+			byID[id.Value] = node
+		}
+	}
+	var ordered []*yaml.Node
+	placed := make(map[*yaml.Node]bool)
+	for _, id := range ids {
+		node, ok := byID[id]
+		if !ok {
+			return invalidEdit("%s has no item %q", describeField(parentID, field), id)
+		}
+		if !placed[node] {
+			placed[node] = true
+			ordered = append(ordered, node)
+		}
+	}
+	for _, node := range seq.Content {
+		if !placed[node] {
+			ordered = append(ordered, node)
+		}
+	}
+	seq.Content = ordered
+	return nil
+}
+
+// itemRef locates an item's mapping node and its place in a list
+type itemRef struct {
+	node  *yaml.Node
+	list  *yaml.Node
+	index int
+}
+
+// find returns the item with the given ID, suggesting close matches if
+// there is none
+func (d *Document) find(id string) (*itemRef, error) {
+	// Mappings without an ID, such as contact, would otherwise match
+	if id == "" {
+		return nil, invalidEdit("an ID is required")
+	}
+	var found *itemRef
+	var walk func(node, list *yaml.Node, index int, section string)
+	walk = func(node, list *yaml.Node, index int, section string) {
+		if found != nil {
+			return
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			if nodeID(node, section) == id {
+				found = &itemRef{node: node, list: list, index: index}
+				return
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i+1], nil, 0, section)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, node, i, section)
+			}
+		}
+	}
+	root := d.root.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		walk(root.Content[i+1], nil, 0, root.Content[i].Value)
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	if r, err := d.Resume(); err == nil {
+		if err := r.Index().Check([]string{id}); err != nil {
+			return nil, unknownIDError{err}
+		}
+	}
+	return nil, unknownIDError{fmt.Errorf("unknown ID %q", id)}
+}
+
+// nodeID returns the ID of an item mapping, falling back to the derived IDs
+// of skills and education entries that do not set one
+func nodeID(node *yaml.Node, section string) string {
+	if id := mappingValue(node, "id"); id != nil {
+		return id.Value
+	}
+	switch section {
+	case SectionSkills:
+		if name := mappingValue(node, "name"); name != nil && mappingValue(node, "items") == nil {
+			return SkillItem{Name: name.Value}.SkillID()
+		}
+	case SectionEducation:
+		if inst := mappingValue(node, "institution"); inst != nil {
+			return EducationEntry{Institution: inst.Value}.EntryID()
+		}
+	}
+	return ""
+}
+
+// setMappingValue sets key in a mapping node, appending it if missing
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// removeMappingKey deletes key from a mapping node if present
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// tagStyle returns the style of the first tags list in the document, so new
+// lists match the existing ones
+func (d *Document) tagStyle() yaml.Style {
+	var style yaml.Style
+	found := false
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if found {
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			if tags := mappingValue(node, "tags"); tags != nil && tags.Kind == yaml.SequenceNode {
+				style, found = tags.Style, true
+				return
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&d.root)
+	return style
+}
+
+// styleNew drops empty fields from a newly encoded item and gives its tags
+// lists the document's style
+func (d *Document) styleNew(node *yaml.Node) {
+	tidyNode(node, d.tagStyle())
+}
+
+// tidyNode drops empty fields below node and gives tags lists style
+func tidyNode(node *yaml.Node, style yaml.Style) {
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			kept := node.Content[:0]
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if isEmptyNode(value) {
+					continue
+				}
+				if key.Value == "tags" && value.Kind == yaml.SequenceNode {
+					value.Style = style
+				}
+				kept = append(kept, key, value)
+			}
+			node.Content = kept
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(node)
+}
+
+// MarshalResume encodes r in the layout of a hand-written resume.yaml:
+// empty fields are left out, tags lists are written inline and top-level
+// sections are separated by blank lines
+func MarshalResume(r *Resume) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(r); err != nil {
+		return nil, err
+	}
+	tidyNode(&node, yaml.FlowStyle)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 && line != "" && line[0] != ' ' && line[0] != '-' && line[0] != '\n' {
+			out.WriteString("\n")
+		}
+		out.WriteString(line)
+	}
+	return []byte(out.String()), nil
+}
+
+// isEmptyNode reports whether a node is null, an empty string or an empty
+// collection
+func isEmptyNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return isNull(node) || (node.Tag == "!!str" && node.Value == "")
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+func describeField(parentID, field string) string {
+	if parentID == "" {
+		return field
+	}
+	return parentID + "." + field
+}
@@ -0,0 +1,252 @@
+package resume
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const documentFixture = `# My resume
+contact:
+  name: Test User # full name
+
+summary: >-
+  First line of a folded
+  summary
+
+experience:
+  # Current job
+  - id: acme
+    title: Engineer
+    company: Acme
+    start_date: Jan 2020
+    end_date: Present
+    tags: [backend]
+    bullets:
+      - id: acme-1
+        text: Built things
+        tags: [go, kafka]
+
+      - id: acme-2
+        text: Fixed things
+        tags: [python]
+
+projects:
+  - id: proj-a
+    title: Project A
+    bullets:
+      - id: pa-1
+        text: Made a tool
+        tags: [rust]
+
+  - id: proj-b
+    title: Project B
+    bullets:
+      - id: pb-1
+        text: Made another tool
+        tags: [go]
+`
+
+func TestDocumentRoundTrip(t *testing.T) {
+	d, err := ParseDocument([]byte(documentFixture))
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+	out, err := d.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	if string(out) != documentFixture {
+		t.Errorf("round trip changed the document:\n%s", out)
+	}
+}
+
+func TestDocumentEdits(t *testing.T) {
+	d, err := ParseDocument([]byte(documentFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.SetText("acme-1", "Built more things"); err != nil {
+		t.Fatalf("SetText failed: %v", err)
+	}
+	if err := d.AddTags("acme-1", "aws", "go"); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	if err := d.RemoveTags("acme-2", "python"); err != nil {
+		t.Fatalf("RemoveTags failed: %v", err)
+	}
+	if err := d.InsertItem("acme", "bullets", 1, Bullet{ID: "acme-new", Text: "Designed it", Tags: []string{"design"}}); err != nil {
+		t.Fatalf("InsertItem failed: %v", err)
+	}
+	if err := d.ReorderItems("", "projects", []string{"proj-b"}); err != nil {
+		t.Fatalf("ReorderItems failed: %v", err)
+	}
+
+	out, err := d.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# My resume\ncontact:\n  name: Test User # full name\n",
+		"summary: >-\n  First line of a folded\n  summary\n",
+		"  # Current job\n  - id: acme\n",
+		"        text: Built more things\n        tags: [go, kafka, aws]\n\n      - id: acme-new\n        text: Designed it\n        tags: [design]\n\n      - id: acme-2\n",
+		"        tags: []\n",
+		"projects:\n  - id: proj-b\n",
+		"        tags: [go]\n\n  - id: proj-a\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	r, err := d.Resume()
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if got := BulletIDs(r.Experience[0].Bullets); !reflect.DeepEqual(got, []string{"acme-1", "acme-new", "acme-2"}) {
+		t.Errorf("acme bullets = %v", got)
+	}
+}
+
+func TestDocumentMoveAndRemove(t *testing.T) {
+	d, err := ParseDocument([]byte(documentFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.MoveItem("acme-2", 0); err != nil {
+		t.Fatalf("MoveItem failed: %v", err)
+	}
+	if err := d.RemoveItem("proj-a"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	r, err := d.Resume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := BulletIDs(r.Experience[0].Bullets); !reflect.DeepEqual(got, []string{"acme-2", "acme-1"}) {
+		t.Errorf("acme bullets = %v", got)
+	}
+	if len(r.Projects) != 1 || r.Projects[0].ID != "proj-b" {
+		t.Errorf("projects = %+v", r.Projects)
+	}
+
+	if err := d.MoveItem("acme-1", 5); !errors.Is(err, ErrInvalidEdit) {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+	if err := d.SetText("acme-3", "x"); err == nil || !strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected suggestion for unknown ID, got %v", err)
+	}
+	if err := d.InsertItem("", "projects", -1, ProjectEntry{ID: "proj-b"}); !errors.Is(err, ErrInvalidEdit) {
+		t.Errorf("expected duplicate ID error, got %v", err)
+	}
+}
+
+func TestDocumentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.yaml")
+	if err := os.WriteFile(path, []byte(documentFixture), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := OpenDocument(path)
+	if err != nil {
+		t.Fatalf("OpenDocument failed: %v", err)
+	}
+	if err := d.SetText("pb-1", "Shipped another tool"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil || string(backup) != documentFixture {
+		t.Errorf("backup = %q, %v", backup, err)
+	}
+	r, err := LoadResume(path)
+	if err != nil {
+		t.Fatalf("LoadResume failed: %v", err)
+	}
+	if got := r.Projects[1].Bullets[0].Text; got != "Shipped another tool" {
+		t.Errorf("saved text = %q", got)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, %v", info.Mode(), err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 2 {
+		t.Errorf("expected only resume.yaml and its backup, got %d files", len(entries))
+	}
+
+	if err := d.SetField("pb-1", "id", "pa-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Save(); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("expected invalid resume error, got %v", err)
+	}
+}
+
+func TestDocumentFieldEdits(t *testing.T) {
+	d, err := ParseDocument([]byte(documentFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.SetValue("test@example.com", "contact", "email"); err != nil {
+		t.Fatalf("SetValue failed: %v", err)
+	}
+	if err := d.SetValue("New summary", "summary"); err != nil {
+		t.Fatalf("SetValue failed: %v", err)
+	}
+	if err := d.SetTags("pa-1", []string{"rust", "cli"}); err != nil {
+		t.Fatalf("SetTags failed: %v", err)
+	}
+	if err := d.SetVariants("pa-1", map[string]string{"short": "Made it"}); err != nil {
+		t.Fatalf("SetVariants failed: %v", err)
+	}
+	if err := d.InsertSkill("languages", SkillItem{Name: "Go", Tags: []string{"go"}}); err != nil {
+		t.Fatalf("InsertSkill failed: %v", err)
+	}
+	if err := d.InsertSkill("languages", SkillItem{Name: "Rust"}); err != nil {
+		t.Fatalf("InsertSkill failed: %v", err)
+	}
+
+	r, err := d.Resume()
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if r.Contact.Name != "Test User" || r.Contact.Email != "test@example.com" {
+		t.Errorf("contact = %+v", r.Contact)
+	}
+	if r.Summary != "New summary" {
+		t.Errorf("summary = %q", r.Summary)
+	}
+	bullet := r.Projects[0].Bullets[0]
+	if !reflect.DeepEqual(bullet.Tags, []string{"rust", "cli"}) || bullet.Variants["short"] != "Made it" {
+		t.Errorf("bullet = %+v", bullet)
+	}
+	var names []string
+	for _, skill := range r.Skills.All() {
+		names = append(names, skill.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Go", "Rust"}) {
+		t.Errorf("skills = %v", names)
+	}
+
+	if err := d.SetText("nope", "x"); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+	if err := d.SetField("", "name", "x"); !errors.Is(err, ErrInvalidEdit) {
+		t.Errorf("expected ErrInvalidEdit for an empty ID, got %v", err)
+	}
+	if err := d.SetField("pb-1", "id", "pa-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Validate(); err == nil || !strings.Contains(err.Error(), `duplicate ID "pa-1"`) {
+		t.Errorf("expected duplicate ID error, got %v", err)
+	}
+}
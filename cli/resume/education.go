@@ -0,0 +1,90 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Education is an ordered list of education entries.
+// In YAML it may be written as a single mapping or as a list.
+type Education []EducationEntry
+
+// EducationEntry represents education details
+type EducationEntry struct {
+	ID          string   `yaml:"id,omitempty" json:"id"`
+	Institution string   `yaml:"institution" json:"institution"`
+	Location    string   `yaml:"location" json:"location"`
+	Degree      string   `yaml:"degree" json:"degree"`
+	Focus       string   `yaml:"focus,omitempty" json:"focus,omitempty"`
+	Program     string   `yaml:"program,omitempty" json:"program,omitempty"`
+	Minor       string   `yaml:"minor" json:"minor"`
+	GPA         string   `yaml:"gpa" json:"gpa"`
+	Honors      string   `yaml:"honors" json:"honors"`
+	StartDate   Date     `yaml:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate     Date     `yaml:"end_date,omitempty" json:"end_date,omitempty"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags"`
+	Coursework  []Bullet `yaml:"coursework,omitempty" json:"coursework"`
+}
+
+// UnmarshalYAML accepts either a single education mapping or a list of them
+func (e *Education) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var entry EducationEntry
+		if err := node.Decode(&entry); err != nil {
+			return err
+		}
+		*e = Education{entry}
+		return nil
+	case yaml.SequenceNode:
+		var entries []EducationEntry
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		*e = entries
+		return nil
+	}
+	return fmt.Errorf("line %d: education must be a mapping or a list of mappings", node.Line)
+}
+
+// EntryID returns the entry's ID, falling back to "edu-<slug>" of its institution
+func (e EducationEntry) EntryID() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return "edu-" + slugify(e.Institution)
+}
+
+// SelectedEducationIDs returns the education entry and coursework IDs present in
+// selectedIDs, or nil if the selection names no education at all (meaning
+// education is not being filtered)
+func (r *Resume) SelectedEducationIDs(selectedIDs map[string]bool) map[string]bool {
+	var eduIDs map[string]bool
+	add := func(id string) {
+		if eduIDs == nil {
+			eduIDs = make(map[string]bool)
+		}
+		eduIDs[id] = true
+	}
+	for _, edu := range r.Education {
+		if selectedIDs[edu.EntryID()] {
+			add(edu.EntryID())
+		}
+		for _, course := range edu.Coursework {
+			if selectedIDs[course.ID] {
+				add(course.ID)
+			}
+		}
+	}
+	return eduIDs
+}
+
+// slugify lowercases s and joins its letters and digits with dashes
+func slugify(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
@@ -0,0 +1,220 @@
+package resume
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Index knows every selectable ID in a resume and its parent/child
+// relationships: experience entries contain positions, positions and
+// projects contain bullets, education entries contain coursework and custom
+// sections contain their items.
+type Index struct {
+	ids        []string
+	parent     map[string]string
+	children   map[string][]string
+	duplicates []string
+}
+
+// Index builds the ID index for r
+func (r *Resume) Index() *Index {
+	ix := &Index{
+		parent:   make(map[string]string),
+		children: make(map[string][]string),
+	}
+
+	for _, exp := range r.Experience {
+		ix.add(exp.ID, "")
+		for _, role := range exp.Roles() {
+			// A single-position entry is its own role and shares its ID
+			if role.ID != exp.ID {
+				ix.add(role.ID, exp.ID)
+			}
+			for _, bullet := range role.Bullets {
+				ix.add(bullet.ID, role.ID)
+			}
+		}
+	}
+	for _, proj := range r.Projects {
+		ix.add(proj.ID, "")
+		for _, bullet := range proj.Bullets {
+			ix.add(bullet.ID, proj.ID)
+		}
+	}
+	for _, lead := range r.Leadership {
+		ix.add(lead.ID, "")
+	}
+	for _, edu := range r.Education {
+		ix.add(edu.EntryID(), "")
+		for _, course := range edu.Coursework {
+			ix.add(course.ID, edu.EntryID())
+		}
+	}
+	for _, skill := range r.Skills.All() {
+		ix.add(skill.SkillID(), "")
+	}
+	for _, section := range r.Sections {
+		ix.add(section.ID, "")
+		for _, item := range section.Items {
+			ix.add(item.ID, section.ID)
+		}
+	}
+	return ix
+}
+
+func (ix *Index) add(id, parent string) {
+	if id == "" {
+		return
+	}
+	if _, ok := ix.parent[id]; ok {
+		ix.duplicates = append(ix.duplicates, id)
+		return
+	}
+	ix.ids = append(ix.ids, id)
+	ix.parent[id] = parent
+	if parent != "" {
+		ix.children[parent] = append(ix.children[parent], id)
+	}
+}
+
+// Duplicates returns IDs used by more than one item, in resume order
+func (ix *Index) Duplicates() []string {
+	return ix.duplicates
+}
+
+// Has reports whether id is a known ID
+func (ix *Index) Has(id string) bool {
+	_, ok := ix.parent[id]
+	return ok
+}
+
+// Parent returns the ID of id's parent, or "" for top-level IDs
+func (ix *Index) Parent(id string) string {
+	return ix.parent[id]
+}
+
+// Children returns the direct children of id
+func (ix *Index) Children(id string) []string {
+	return ix.children[id]
+}
+
+// Descendants returns every ID below id, depth first
+func (ix *Index) Descendants(id string) []string {
+	var ids []string
+	for _, child := range ix.children[id] {
+		ids = append(ids, child)
+		ids = append(ids, ix.Descendants(child)...)
+	}
+	return ids
+}
+
+// Expand resolves a list of IDs to the selected set. Selecting a parent
+// selects all of its descendants, and an ID written as "-id" removes it and
+// its descendants after all inclusions are applied. A list made only of
+// exclusions starts from everything. Unknown IDs are ignored; use Check to
+// report them.
+func (ix *Index) Expand(ids []string) map[string]bool {
+	selected := make(map[string]bool)
+	var excluded []string
+	for _, id := range ids {
+		if strings.HasPrefix(id, "-") {
+			excluded = append(excluded, strings.TrimPrefix(id, "-"))
+			continue
+		}
+		selected[id] = true
+		for _, child := range ix.Descendants(id) {
+			selected[child] = true
+		}
+	}
+
+	if len(selected) == 0 && len(excluded) > 0 {
+		for _, id := range ix.ids {
+			selected[id] = true
+		}
+	}
+	for _, id := range excluded {
+		delete(selected, id)
+		for _, child := range ix.Descendants(id) {
+			delete(selected, child)
+		}
+	}
+	return selected
+}
+
+// Check reports IDs (with or without a leading "-") that are not in the
+// index, suggesting close matches
+func (ix *Index) Check(ids []string) error {
+	var unknown []string
+	for _, id := range ids {
+		id = strings.TrimPrefix(id, "-")
+		if ix.Has(id) {
+			continue
+		}
+		msg := fmt.Sprintf("%q", id)
+		if suggestions := ix.Suggest(id); len(suggestions) > 0 {
+			msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, ", "))
+		}
+		unknown = append(unknown, msg)
+	}
+	if len(unknown) == 1 {
+		return fmt.Errorf("unknown ID %s", unknown[0])
+	}
+	if len(unknown) > 1 {
+		return fmt.Errorf("unknown IDs %s", strings.Join(unknown, "; "))
+	}
+	return nil
+}
+
+// maxSuggestions caps how many close matches Suggest returns
+const maxSuggestions = 3
+
+// Suggest returns up to three known IDs close to id, closest first. An ID
+// matches if it is within a small edit distance of id or, for ids of three
+// or more characters, contains it (or vice versa).
+func (ix *Index) Suggest(id string) []string {
+	type candidate struct {
+		id       string
+		distance int
+	}
+	limit := len(id)/3 + 1
+	var candidates []candidate
+	for _, known := range ix.ids {
+		d := editDistance(id, known)
+		contains := len(id) >= 3 && (strings.Contains(known, id) || strings.Contains(id, known))
+		if d > limit && !contains {
+			continue
+		}
+		candidates = append(candidates, candidate{known, d})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].id)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
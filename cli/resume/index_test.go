@@ -0,0 +1,61 @@
+package resume
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sortedIDs(set map[string]bool) []string {
+	var ids []string
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestIndexExpand(t *testing.T) {
+	ix := queryTestResume().Index()
+	tests := []struct {
+		ids  []string
+		want []string
+	}{
+		{[]string{"s1"}, []string{"s1"}},
+		{[]string{"acme-intern"}, []string{"acme-intern", "i1"}},
+		{[]string{"acme", "-acme-intern"}, []string{"acme", "acme-senior", "s1", "s2"}},
+		{[]string{"-s2", "proj"}, []string{"p1", "proj"}},
+		{[]string{"talks"}, []string{"t1", "talks"}},
+		{[]string{"-acme", "-talks"}, []string{"p1", "proj"}},
+	}
+	for _, tt := range tests {
+		if got := sortedIDs(ix.Expand(tt.ids)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%v) = %v, want %v", tt.ids, got, tt.want)
+		}
+	}
+
+	if got := ix.Parent("i1"); got != "acme-intern" {
+		t.Errorf("Parent(i1) = %q", got)
+	}
+	if got := ix.Parent("acme-intern"); got != "acme" {
+		t.Errorf("Parent(acme-intern) = %q", got)
+	}
+}
+
+func TestIndexCheck(t *testing.T) {
+	ix := queryTestResume().Index()
+	if err := ix.Check([]string{"acme", "-s2", "talks"}); err != nil {
+		t.Errorf("Check failed: %v", err)
+	}
+
+	err := ix.Check([]string{"acme-inter"})
+	if err == nil || !strings.Contains(err.Error(), `unknown ID "acme-inter" (did you mean acme-intern`) {
+		t.Errorf("expected suggestion, got %v", err)
+	}
+
+	err = ix.Check([]string{"-nope", "zzzzzzzz"})
+	if err == nil || !strings.Contains(err.Error(), `unknown IDs "nope"`) || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected unknown IDs without suggestions, got %v", err)
+	}
+}
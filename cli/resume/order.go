@@ -0,0 +1,248 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Top-level resume section names
+const (
+	SectionSummary    = "summary"
+	SectionEducation  = "education"
+	SectionSkills     = "skills"
+	SectionExperience = "experience"
+	SectionProjects   = "projects"
+	SectionLeadership = "leadership"
+)
+
+// DefaultSections is the section sequence used when none is configured
+var DefaultSections = []string{
+	SectionSummary,
+	SectionEducation,
+	SectionSkills,
+	SectionExperience,
+	SectionProjects,
+	SectionLeadership,
+}
+
+// ValidateSections checks that every name in a section sequence is a
+// built-in section or one of the resume's custom sections
+func (r *Resume) ValidateSections(sections []string) error {
+	available := r.SectionNames()
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+	seen := make(map[string]bool, len(sections))
+	for _, name := range sections {
+		if !known[name] {
+			return fmt.Errorf("unknown section %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("section %q listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// SectionOrder represents custom ordering for resume sections
+type SectionOrder struct {
+	// Sections is the rendered section sequence; sections left out are hidden
+	Sections   []string `yaml:"sections,omitempty" json:"sections,omitempty"`
+	Education  []string `yaml:"education,omitempty" json:"education,omitempty"`
+	Experience []string `yaml:"experience" json:"experience"`
+	Projects   []string `yaml:"projects" json:"projects"`
+	Leadership []string `yaml:"leadership" json:"leadership"`
+	// Bullets maps an experience, position, project, education or custom
+	// section ID to the order of its children: positions for an experience
+	// entry that has them, otherwise its bullet, course or item IDs
+	Bullets map[string][]string `yaml:"bullets,omitempty" json:"bullets,omitempty"`
+}
+
+// SectionSequence returns the configured section sequence, or every section
+// of r in default order
+func (o *SectionOrder) SectionSequence(r *Resume) []string {
+	if o == nil || len(o.Sections) == 0 {
+		return r.SectionNames()
+	}
+	return o.Sections
+}
+
+// BulletOrder returns the bullet order for an entry, or nil for YAML order
+func (o *SectionOrder) BulletOrder(entryID string) []string {
+	if o == nil {
+		return nil
+	}
+	return o.Bullets[entryID]
+}
+
+// OrderPath returns the location of order.yaml for the given resume file
+func OrderPath(resumePath string) string {
+	return filepath.Join(filepath.Dir(resumePath), "order.yaml")
+}
+
+// LoadOrder reads order.yaml or returns default order from resume
+func LoadOrder(orderPath string, r *Resume) (*SectionOrder, error) {
+	data, err := os.ReadFile(orderPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Return default order from resume
+			return GetDefaultOrder(r), nil
+		}
+		return nil, err
+	}
+
+	var order SectionOrder
+	if err := yaml.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// SaveOrder writes order to order.yaml
+func SaveOrder(orderPath string, order *SectionOrder) error {
+	data, err := yaml.Marshal(order)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(orderPath, data, 0644)
+}
+
+// GetDefaultOrder extracts IDs in their original YAML order
+func GetDefaultOrder(r *Resume) *SectionOrder {
+	order := &SectionOrder{
+		Education:  make([]string, len(r.Education)),
+		Experience: make([]string, len(r.Experience)),
+		Projects:   make([]string, len(r.Projects)),
+		Leadership: make([]string, len(r.Leadership)),
+	}
+
+	for i, edu := range r.Education {
+		order.Education[i] = edu.EntryID()
+	}
+	for i, exp := range r.Experience {
+		order.Experience[i] = exp.ID
+	}
+	for i, proj := range r.Projects {
+		order.Projects[i] = proj.ID
+	}
+	for i, lead := range r.Leadership {
+		order.Leadership[i] = lead.ID
+	}
+
+	return order
+}
+
+// GetChronologicalOrder is GetDefaultOrder with experience and education
+// sorted newest first: by end date ("Present" first), then by start date
+func GetChronologicalOrder(r *Resume, now time.Time) *SectionOrder {
+	order := GetDefaultOrder(r)
+
+	experience := append([]ExperienceEntry(nil), r.Experience...)
+	sort.SliceStable(experience, func(i, j int) bool {
+		startI, endI := experience[i].Span(now)
+		startJ, endJ := experience[j].Span(now)
+		return newerThan(startI, endI, startJ, endJ, now)
+	})
+	for i, exp := range experience {
+		order.Experience[i] = exp.ID
+	}
+
+	education := append(Education(nil), r.Education...)
+	sort.SliceStable(education, func(i, j int) bool {
+		return newerThan(education[i].StartDate, education[i].EndDate, education[j].StartDate, education[j].EndDate, now)
+	})
+	for i, edu := range education {
+		order.Education[i] = edu.EntryID()
+	}
+
+	return order
+}
+
+// newerThan reports whether the first date range ended (or, on a tie, started) later
+func newerThan(startA, endA, startB, endB Date, now time.Time) bool {
+	if endB.Before(endA, now) {
+		return true
+	}
+	if endA.Before(endB, now) {
+		return false
+	}
+	return startB.Before(startA, now)
+}
+
+// PartialSectionOrder allows updating a single section's order.
+type PartialSectionOrder struct {
+	Sections   *[]string `yaml:"sections,omitempty" json:"sections,omitempty"`
+	Education  *[]string `yaml:"education,omitempty" json:"education,omitempty"`
+	Experience *[]string `yaml:"experience,omitempty" json:"experience,omitempty"`
+	Projects   *[]string `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Leadership *[]string `yaml:"leadership,omitempty" json:"leadership,omitempty"`
+	// Bullets replaces the bullet order of each listed entry; an empty list resets it
+	Bullets map[string][]string `yaml:"bullets,omitempty" json:"bullets,omitempty"`
+}
+
+// MergeOrder applies a partial order update to an existing SectionOrder.
+func MergeOrder(existing *SectionOrder, partial *PartialSectionOrder) {
+	if partial.Sections != nil {
+		existing.Sections = *partial.Sections
+	}
+	if partial.Education != nil {
+		existing.Education = *partial.Education
+	}
+	if partial.Experience != nil {
+		existing.Experience = *partial.Experience
+	}
+	if partial.Projects != nil {
+		existing.Projects = *partial.Projects
+	}
+	if partial.Leadership != nil {
+		existing.Leadership = *partial.Leadership
+	}
+	for entryID, bulletIDs := range partial.Bullets {
+		if len(bulletIDs) == 0 {
+			delete(existing.Bullets, entryID)
+			continue
+		}
+		if existing.Bullets == nil {
+			existing.Bullets = make(map[string][]string)
+		}
+		existing.Bullets[entryID] = bulletIDs
+	}
+}
+
+// toOrderMap builds a map from ID to position for O(1) lookup.
+func toOrderMap(ids []string) map[string]int {
+	m := make(map[string]int, len(ids))
+	for i, id := range ids {
+		m[id] = i
+	}
+	return m
+}
+
+// SortByOrder sorts a slice in-place according to an ordered list of IDs.
+// Items not in the order list are placed at the end, preserving relative order.
+func SortByOrder[T any](items []T, orderIDs []string, getID func(T) string) {
+	if len(orderIDs) == 0 {
+		return
+	}
+	om := toOrderMap(orderIDs)
+	fallback := len(items)
+	sort.SliceStable(items, func(i, j int) bool {
+		iOrder, iOk := om[getID(items[i])]
+		jOrder, jOk := om[getID(items[j])]
+		if !iOk {
+			iOrder = fallback
+		}
+		if !jOk {
+			jOrder = fallback
+		}
+		return iOrder < jOrder
+	})
+}
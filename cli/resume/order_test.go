@@ -0,0 +1,167 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func orderTestResume() *Resume {
+	return &Resume{
+		Experience: []ExperienceEntry{{ID: "exp-a"}, {ID: "exp-b"}},
+		Projects:   []ProjectEntry{{ID: "proj-a"}, {ID: "proj-b"}, {ID: "proj-c"}},
+		Leadership: []LeadershipEntry{{ID: "lead-a"}},
+	}
+}
+
+func TestLoadOrderDefault(t *testing.T) {
+	order, err := LoadOrder(filepath.Join(t.TempDir(), "order.yaml"), orderTestResume())
+	if err != nil {
+		t.Fatalf("LoadOrder failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(order.Projects, []string{"proj-a", "proj-b", "proj-c"}) {
+		t.Errorf("default project order = %v", order.Projects)
+	}
+}
+
+func TestSaveAndLoadOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "order.yaml")
+	order := GetDefaultOrder(orderTestResume())
+	projects := []string{"proj-c", "proj-a"}
+	MergeOrder(order, &PartialSectionOrder{Projects: &projects})
+
+	if err := SaveOrder(path, order); err != nil {
+		t.Fatalf("SaveOrder failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("order file not written: %v", err)
+	}
+
+	loaded, err := LoadOrder(path, orderTestResume())
+	if err != nil {
+		t.Fatalf("LoadOrder failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Projects, projects) {
+		t.Errorf("Projects = %v, want %v", loaded.Projects, projects)
+	}
+	if !reflect.DeepEqual(loaded.Experience, []string{"exp-a", "exp-b"}) {
+		t.Errorf("Experience should be unchanged, got %v", loaded.Experience)
+	}
+}
+
+func TestOrderPath(t *testing.T) {
+	got := OrderPath(filepath.Join("data", "resume.yaml"))
+	if got != filepath.Join("data", "order.yaml") {
+		t.Errorf("OrderPath = %q", got)
+	}
+}
+
+func TestSortByOrder(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	SortByOrder(items, []string{"c", "a"}, func(s string) string { return s })
+
+	want := []string{"c", "a", "b", "d"}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("SortByOrder = %v, want %v", items, want)
+	}
+}
+
+func TestMergeOrderBullets(t *testing.T) {
+	order := GetDefaultOrder(orderTestResume())
+
+	MergeOrder(order, &PartialSectionOrder{Bullets: map[string][]string{
+		"exp-a": {"b2", "b1"},
+		"exp-b": {"b4", "b3"},
+	}})
+	if !reflect.DeepEqual(order.BulletOrder("exp-a"), []string{"b2", "b1"}) {
+		t.Errorf("BulletOrder(exp-a) = %v", order.BulletOrder("exp-a"))
+	}
+
+	// An empty list resets one entry without touching the others
+	MergeOrder(order, &PartialSectionOrder{Bullets: map[string][]string{"exp-a": {}}})
+	if order.BulletOrder("exp-a") != nil {
+		t.Errorf("BulletOrder(exp-a) should be reset, got %v", order.BulletOrder("exp-a"))
+	}
+	if !reflect.DeepEqual(order.BulletOrder("exp-b"), []string{"b4", "b3"}) {
+		t.Errorf("BulletOrder(exp-b) = %v", order.BulletOrder("exp-b"))
+	}
+
+	var nilOrder *SectionOrder
+	if nilOrder.BulletOrder("exp-a") != nil {
+		t.Error("BulletOrder on nil order should return nil")
+	}
+}
+
+func TestSectionSequence(t *testing.T) {
+	var nilOrder *SectionOrder
+	if !reflect.DeepEqual(nilOrder.SectionSequence(nil), DefaultSections) {
+		t.Error("nil order should use DefaultSections")
+	}
+
+	r := &Resume{Sections: []CustomSection{{ID: "certifications", Title: "Certifications"}}}
+	want := append(append([]string(nil), DefaultSections...), "certifications")
+	if got := nilOrder.SectionSequence(r); !reflect.DeepEqual(got, want) {
+		t.Errorf("SectionSequence = %v, want %v", got, want)
+	}
+
+	order := GetDefaultOrder(orderTestResume())
+	sections := []string{SectionProjects, SectionExperience}
+	MergeOrder(order, &PartialSectionOrder{Sections: &sections})
+	if !reflect.DeepEqual(order.SectionSequence(r), sections) {
+		t.Errorf("SectionSequence = %v, want %v", order.SectionSequence(r), sections)
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	r := &Resume{Sections: []CustomSection{{ID: "awards", Title: "Awards"}}}
+	if err := r.ValidateSections([]string{SectionProjects, SectionEducation, "awards"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.ValidateSections([]string{"hobbies"}); err == nil {
+		t.Error("expected error for unknown section")
+	}
+	if err := r.ValidateSections([]string{SectionSkills, SectionSkills}); err == nil {
+		t.Error("expected error for duplicate section")
+	}
+	var nilResume *Resume
+	if err := nilResume.ValidateSections([]string{"awards"}); err == nil {
+		t.Error("expected error for custom section without a resume")
+	}
+}
+
+func TestGetChronologicalOrder(t *testing.T) {
+	date := func(s string) Date {
+		d, err := ParseDate(s)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) failed: %v", s, err)
+		}
+		return d
+	}
+	r := &Resume{
+		Experience: []ExperienceEntry{
+			{ID: "old", StartDate: date("2018"), EndDate: date("2019")},
+			{ID: "current", StartDate: date("Aug 2023"), EndDate: date("Present")},
+			{ID: "recent", StartDate: date("June 2021"), EndDate: date("August 2023")},
+			{ID: "internship", StartDate: date("June 2022"), EndDate: date("August 2023")},
+		},
+		Education: Education{
+			{ID: "bs", EndDate: date("May 2020")},
+			{ID: "ms", EndDate: date("May 2022")},
+		},
+	}
+
+	order := GetChronologicalOrder(r, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	wantExp := []string{"current", "internship", "recent", "old"}
+	if !reflect.DeepEqual(order.Experience, wantExp) {
+		t.Errorf("Experience = %v, want %v", order.Experience, wantExp)
+	}
+	if !reflect.DeepEqual(order.Education, []string{"ms", "bs"}) {
+		t.Errorf("Education = %v, want [ms bs]", order.Education)
+	}
+	if r.Experience[0].ID != "old" {
+		t.Error("GetChronologicalOrder should not reorder the resume")
+	}
+}
@@ -6,7 +6,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// LoadResume reads and parses the resume YAML file
+// LoadResume reads and parses the resume YAML file, along with the tag
+// taxonomy in tags.yaml next to it if there is one
 func LoadResume(path string) (*Resume, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -18,5 +19,15 @@ func LoadResume(path string) (*Resume, error) {
 		return nil, err
 	}
 
+	if err := resume.validateSections(); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := LoadTaxonomy(TaxonomyPath(path))
+	if err != nil {
+		return nil, err
+	}
+	resume.Taxonomy = taxonomy
+
 	return &resume, nil
 }
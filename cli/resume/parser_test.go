@@ -85,6 +85,131 @@ leadership: []
 	if resume.Experience[0].Bullets[0].ID != "test-bullet" {
 		t.Errorf("Expected bullet ID 'test-bullet', got '%s'", resume.Experience[0].Bullets[0].ID)
 	}
+
+	// A single education mapping loads as one entry
+	if len(resume.Education) != 1 || resume.Education[0].Institution != "Test University" {
+		t.Errorf("Expected one education entry for Test University, got %+v", resume.Education)
+	}
+
+	// Legacy skills mapping keeps its key order
+	if len(resume.Skills) != 3 {
+		t.Fatalf("Expected 3 skill categories, got %d", len(resume.Skills))
+	}
+	if resume.Skills[1].Name != "frameworks" || resume.Skills[1].DisplayLabel() != "Frameworks & Tools" {
+		t.Errorf("Unexpected second category: %+v", resume.Skills[1])
+	}
+	if resume.Skills[0].Items[0].Name != "Go" {
+		t.Errorf("Expected first skill 'Go', got '%s'", resume.Skills[0].Items[0].Name)
+	}
+}
+
+func TestLoadResumeSkillCategories(t *testing.T) {
+	content := `skills:
+  - name: databases
+    items:
+      - name: PostgreSQL
+        tags: [sql]
+  - name: certifications
+    label: Certifications & Licenses
+    items:
+      - id: aws-sa
+        name: AWS Certified Solutions Architect
+  - name: spoken-languages
+    items:
+      - name: Mandarin
+  - name: éducation-continue
+    items:
+      - name: Rust
+`
+
+	tmpFile, err := os.CreateTemp("", "resume-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	resume, err := LoadResume(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to load resume: %v", err)
+	}
+
+	if len(resume.Skills) != 4 {
+		t.Fatalf("Expected 4 skill categories, got %d", len(resume.Skills))
+	}
+
+	labels := []string{"Databases", "Certifications & Licenses", "Spoken Languages", "Éducation Continue"}
+	for i, label := range labels {
+		if got := resume.Skills[i].DisplayLabel(); got != label {
+			t.Errorf("Category %d label = %q, want %q", i, got, label)
+		}
+	}
+
+	if id := resume.Skills[1].Items[0].SkillID(); id != "aws-sa" {
+		t.Errorf("Expected skill ID 'aws-sa', got '%s'", id)
+	}
+	if len(resume.Skills.All()) != 4 {
+		t.Errorf("Expected 4 skills in total, got %d", len(resume.Skills.All()))
+	}
+}
+
+func TestLoadResumeEducationList(t *testing.T) {
+	content := `education:
+  - id: grad
+    institution: Graduate School
+    degree: M.S. Computer Science
+    start_date: Aug 2023
+    end_date: May 2025
+    coursework:
+      - id: course-ds
+        text: Distributed Systems
+  - institution: Test University
+    degree: B.S. Computer Science
+`
+
+	tmpFile, err := os.CreateTemp("", "resume-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	resume, err := LoadResume(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to load resume: %v", err)
+	}
+
+	if len(resume.Education) != 2 {
+		t.Fatalf("Expected 2 education entries, got %d", len(resume.Education))
+	}
+	if id := resume.Education[0].EntryID(); id != "grad" {
+		t.Errorf("Expected entry ID 'grad', got '%s'", id)
+	}
+	if id := resume.Education[1].EntryID(); id != "edu-test-university" {
+		t.Errorf("Expected entry ID 'edu-test-university', got '%s'", id)
+	}
+	if len(resume.Education[0].Coursework) != 1 || resume.Education[0].Coursework[0].ID != "course-ds" {
+		t.Errorf("Unexpected coursework: %+v", resume.Education[0].Coursework)
+	}
+
+	ids := resume.GetAllIDs()
+	found := map[string]bool{}
+	for _, item := range ids {
+		found[item.ID] = true
+	}
+	for _, id := range []string{"grad", "edu-test-university", "course-ds"} {
+		if !found[id] {
+			t.Errorf("GetAllIDs missing %q", id)
+		}
+	}
 }
 
 func TestLoadResumeFileNotFound(t *testing.T) {
@@ -93,3 +218,90 @@ func TestLoadResumeFileNotFound(t *testing.T) {
 		t.Error("Expected error for nonexistent file, got nil")
 	}
 }
+
+func TestLoadResumeCustomSections(t *testing.T) {
+	content := `sections:
+  - id: certifications
+    title: Certifications
+    layout: entries
+    items:
+      - id: cert-aws
+        title: AWS Solutions Architect
+        subtitle: Amazon Web Services
+        date: "2024"
+        tags: [aws, cloud]
+  - id: languages-spoken
+    title: Languages
+    layout: table
+    items:
+      - id: lang-es
+        key: Spanish
+        value: Professional working proficiency
+  - id: awards
+    title: Awards
+    items:
+      - id: award-hackathon
+        text: First place, HackOHI/O
+`
+
+	tmpFile, err := os.CreateTemp("", "resume-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	resume, err := LoadResume(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to load resume: %v", err)
+	}
+
+	if len(resume.Sections) != 3 {
+		t.Fatalf("Expected 3 custom sections, got %d", len(resume.Sections))
+	}
+	if layout := resume.Sections[2].LayoutName(); layout != LayoutList {
+		t.Errorf("Expected default layout %q, got %q", LayoutList, layout)
+	}
+	if text := resume.Sections[0].Items[0].DisplayText(); text != "AWS Solutions Architect, Amazon Web Services (2024)" {
+		t.Errorf("Unexpected display text %q", text)
+	}
+	if text := resume.Sections[1].Items[0].DisplayText(); text != "Spanish: Professional working proficiency" {
+		t.Errorf("Unexpected display text %q", text)
+	}
+
+	selected := resume.FilterByTags([]string{"aws"})
+	if !selected["cert-aws"] || len(selected) != 1 {
+		t.Errorf("FilterByTags = %v, want only cert-aws", selected)
+	}
+}
+
+func TestLoadResumeInvalidCustomSections(t *testing.T) {
+	tests := map[string]string{
+		"missing id": "sections:\n  - title: Awards\n",
+		"builtin id": "sections:\n  - id: skills\n    title: More Skills\n",
+		"duplicate":  "sections:\n  - id: awards\n  - id: awards\n",
+		"bad layout": "sections:\n  - id: awards\n    layout: grid\n",
+		"item id":    "sections:\n  - id: awards\n    items:\n      - text: Missing ID\n",
+	}
+
+	for name, content := range tests {
+		tmpFile, err := os.CreateTemp("", "resume-*.yaml")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %v", err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write to temp file: %v", err)
+		}
+		tmpFile.Close()
+
+		if _, err := LoadResume(tmpFile.Name()); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
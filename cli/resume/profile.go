@@ -0,0 +1,191 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named, saved selection for generating a tailored resume
+type Profile struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// IDs selects items by ID as for FilterByIDs; a bullet may be written
+	// as "id:variant"
+	IDs []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	// Tags selects every item carrying one of the tags
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// Query selects every item matching a boolean tag query
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+	// Skills picks skills by ID or name, replacing any chosen by IDs or Tags
+	Skills []string `yaml:"skills,omitempty" json:"skills,omitempty"`
+	// Order overrides order.yaml for the sections and entries it lists
+	Order    *PartialSectionOrder `yaml:"order,omitempty" json:"order,omitempty"`
+	Template string               `yaml:"template,omitempty" json:"template,omitempty"`
+	Variant  string               `yaml:"variant,omitempty" json:"variant,omitempty"`
+}
+
+// Profiles maps profile names to profiles, as stored in profiles.yaml
+type Profiles map[string]*Profile
+
+// profileNamePattern restricts names to ones usable in URLs and file listings
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateProfileName checks that a profile name is lowercase letters,
+// digits, hyphens and underscores
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use lowercase letters, digits, - and _)", name)
+	}
+	return nil
+}
+
+// ProfilesPath returns the location of profiles.yaml for the given resume file
+func ProfilesPath(resumePath string) string {
+	return filepath.Join(filepath.Dir(resumePath), "profiles.yaml")
+}
+
+// LoadProfiles reads profiles.yaml, returning no profiles if it does not exist
+func LoadProfiles(profilesPath string) (Profiles, error) {
+	data, err := os.ReadFile(profilesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Profiles{}, nil
+		}
+		return nil, err
+	}
+
+	profiles := Profiles{}
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("%s: %w", profilesPath, err)
+	}
+	for name, profile := range profiles {
+		if err := ValidateProfileName(name); err != nil {
+			return nil, fmt.Errorf("%s: %w", profilesPath, err)
+		}
+		if profile == nil {
+			profiles[name] = &Profile{}
+		}
+	}
+	return profiles, nil
+}
+
+// SaveProfiles writes profiles to profiles.yaml
+func SaveProfiles(profilesPath string, profiles Profiles) error {
+	data, err := yaml.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(profilesPath, data, 0644)
+}
+
+// Names returns the profile names in sorted order
+func (p Profiles) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named profile
+func (p Profiles) Get(name string) (*Profile, error) {
+	profile, ok := p[name]
+	if !ok {
+		if len(p) == 0 {
+			return nil, fmt.Errorf("unknown profile %q (no profiles saved)", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(p.Names(), ", "))
+	}
+	return profile, nil
+}
+
+// Validate checks the profile's query, sections, skills and variant against r
+func (p *Profile) Validate(r *Resume) error {
+	if p.Query != "" {
+		q, err := ParseQuery(p.Query)
+		if err != nil {
+			return err
+		}
+		if err := r.ValidateQuery(q); err != nil {
+			return err
+		}
+	}
+	if p.Order != nil && p.Order.Sections != nil {
+		if err := r.ValidateSections(*p.Order.Sections); err != nil {
+			return err
+		}
+	}
+	if err := r.ValidateVariant(p.Variant); err != nil {
+		return err
+	}
+	ids, variants := SplitVariants(p.IDs)
+	if err := r.Index().Check(ids); err != nil {
+		return err
+	}
+	for id, variant := range variants {
+		if err := r.ValidateVariant(variant); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+	}
+	for _, key := range p.Skills {
+		if len(r.Skills.ResolveIDs([]string{key})) == 0 {
+			return fmt.Errorf("unknown skill %q", key)
+		}
+	}
+	return nil
+}
+
+// Selection resolves the profile to selected item IDs and per-bullet
+// variants. An empty selection means every item is included.
+func (p *Profile) Selection(r *Resume) (map[string]bool, map[string]string) {
+	ids, variants := SplitVariants(p.IDs)
+	selected := r.FilterByIDs(ids)
+	if len(ids) == 0 && len(p.Tags) == 0 && p.Query == "" && len(p.Skills) > 0 {
+		// Only skills are picked, so everything else stays included
+		for _, item := range r.GetAllIDs() {
+			selected[item.ID] = true
+		}
+	}
+	if len(p.Tags) > 0 {
+		for id := range r.FilterByTags(p.Tags) {
+			selected[id] = true
+		}
+	}
+	if p.Query != "" {
+		// An invalid query is reported by Validate
+		if q, err := ParseQuery(p.Query); err == nil {
+			for id := range r.FilterByQuery(q) {
+				selected[id] = true
+			}
+		}
+	}
+	if len(p.Skills) > 0 {
+		for _, skill := range r.Skills.All() {
+			delete(selected, skill.SkillID())
+		}
+		for _, id := range r.Skills.ResolveIDs(p.Skills) {
+			selected[id] = true
+		}
+	}
+	return selected, variants
+}
+
+// ApplyOrder returns a copy of order with the profile's overrides applied
+func (p *Profile) ApplyOrder(order *SectionOrder) *SectionOrder {
+	merged := *order
+	if p.Order == nil {
+		return &merged
+	}
+	merged.Bullets = make(map[string][]string, len(order.Bullets))
+	for id, ids := range order.Bullets {
+		merged.Bullets[id] = ids
+	}
+	MergeOrder(&merged, p.Order)
+	return &merged
+}
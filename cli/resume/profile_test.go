@@ -0,0 +1,125 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func profileTestResume() *Resume {
+	return &Resume{
+		Skills: Skills{
+			{Name: "languages", Items: []SkillItem{
+				{Name: "Go", Tags: []string{"go"}},
+				{Name: "Python", Tags: []string{"python"}},
+			}},
+		},
+		Experience: []ExperienceEntry{{
+			ID: "exp-a",
+			Bullets: []Bullet{
+				{ID: "a1", Tags: []string{"go"}},
+				{ID: "a2", Tags: []string{"python"}, Variants: map[string]string{"short": "Short"}},
+			},
+		}},
+		Projects: []ProjectEntry{{ID: "proj-a", Bullets: []Bullet{{ID: "p1", Tags: []string{"rust"}}}}},
+	}
+}
+
+func TestLoadProfilesMissing(t *testing.T) {
+	profiles, err := LoadProfiles(filepath.Join(t.TempDir(), "profiles.yaml"))
+	if err != nil {
+		t.Fatalf("LoadProfiles failed: %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected no profiles, got %v", profiles)
+	}
+	if _, err := profiles.Get("backend"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestSaveAndLoadProfiles(t *testing.T) {
+	path := ProfilesPath(filepath.Join(t.TempDir(), "resume.yaml"))
+	sections := []string{"experience", "skills"}
+	profiles := Profiles{
+		"backend": {
+			Description: "Backend roles",
+			Tags:        []string{"go"},
+			Skills:      []string{"Go"},
+			Order:       &PartialSectionOrder{Sections: &sections},
+			Template:    "classic",
+		},
+		"embedded": {IDs: []string{"p1"}},
+	}
+	if err := SaveProfiles(path, profiles); err != nil {
+		t.Fatalf("SaveProfiles failed: %v", err)
+	}
+
+	loaded, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Names(), []string{"backend", "embedded"}) {
+		t.Errorf("Names = %v", loaded.Names())
+	}
+	if !reflect.DeepEqual(loaded["backend"], profiles["backend"]) {
+		t.Errorf("backend = %+v, want %+v", loaded["backend"], profiles["backend"])
+	}
+
+	if err := os.WriteFile(path, []byte("Bad Name:\n  tags: [go]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProfiles(path); err == nil || !strings.Contains(err.Error(), "invalid profile name") {
+		t.Errorf("expected invalid name error, got %v", err)
+	}
+}
+
+func TestProfileSelection(t *testing.T) {
+	r := profileTestResume()
+
+	profile := &Profile{IDs: []string{"p1", "a2:short"}, Tags: []string{"go"}, Skills: []string{"Python"}}
+	if err := profile.Validate(r); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	selected, variants := profile.Selection(r)
+	want := map[string]bool{"p1": true, "a2": true, "a1": true, "skill-python": true}
+	if !reflect.DeepEqual(selected, want) {
+		t.Errorf("Selection = %v, want %v", selected, want)
+	}
+	if variants["a2"] != "short" {
+		t.Errorf("variants = %v, want a2:short", variants)
+	}
+
+	// Picking only skills keeps every other item
+	selected, _ = (&Profile{Skills: []string{"skill-go"}}).Selection(r)
+	if !selected["a1"] || !selected["p1"] || !selected["skill-go"] || selected["skill-python"] {
+		t.Errorf("skills-only Selection = %v", selected)
+	}
+
+	if err := (&Profile{Skills: []string{"Haskell"}}).Validate(r); err == nil {
+		t.Error("expected error for unknown skill")
+	}
+	if err := (&Profile{Variant: "long"}).Validate(r); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+}
+
+func TestProfileApplyOrder(t *testing.T) {
+	order := GetDefaultOrder(orderTestResume())
+	order.Bullets = map[string][]string{"exp-a": {"a2", "a1"}}
+	projects := []string{"proj-c"}
+	profile := &Profile{Order: &PartialSectionOrder{
+		Projects: &projects,
+		Bullets:  map[string][]string{"exp-a": nil},
+	}}
+
+	merged := profile.ApplyOrder(order)
+	if !reflect.DeepEqual(merged.Projects, projects) || merged.BulletOrder("exp-a") != nil {
+		t.Errorf("merged order = %+v", merged)
+	}
+	if len(order.Projects) != 3 || order.BulletOrder("exp-a") == nil {
+		t.Error("ApplyOrder mutated the original order")
+	}
+}
@@ -0,0 +1,333 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Query is a parsed boolean tag query such as
+//
+//	go AND (distributed-systems OR kafka) AND NOT internship
+//
+// Terms are tags, or section:<name> to match items of one section (a
+// built-in section or a custom section ID). Operators are AND, OR and NOT
+// (case-insensitive) with parentheses for grouping; AND binds tighter than
+// OR, and terms written side by side are ANDed.
+type Query struct {
+	source string
+	root   queryNode
+}
+
+// queryNode is a node of a parsed query
+type queryNode interface {
+	match(item queryItem) bool
+}
+
+// queryItem is what a query is evaluated against
+type queryItem struct {
+	id      string
+	section string
+	tags    map[string]bool
+}
+
+type (
+	andNode     struct{ left, right queryNode }
+	orNode      struct{ left, right queryNode }
+	notNode     struct{ operand queryNode }
+	tagNode     struct{ tag string }
+	sectionNode struct{ section string }
+)
+
+func (n andNode) match(item queryItem) bool     { return n.left.match(item) && n.right.match(item) }
+func (n orNode) match(item queryItem) bool      { return n.left.match(item) || n.right.match(item) }
+func (n notNode) match(item queryItem) bool     { return !n.operand.match(item) }
+func (n tagNode) match(item queryItem) bool     { return item.tags[n.tag] }
+func (n sectionNode) match(item queryItem) bool { return item.section == n.section }
+
+// ParseQuery parses a boolean tag query
+func ParseQuery(s string) (*Query, error) {
+	tokens := tokenizeQuery(s)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	p := &queryParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != nil {
+		return nil, fmt.Errorf("query: unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return &Query{source: s, root: root}, nil
+}
+
+// String returns the query as written
+func (q *Query) String() string {
+	return q.source
+}
+
+// Sections returns the section names the query scopes to, so callers can
+// check them against the resume
+func (q *Query) Sections() []string {
+	var sections []string
+	var walk func(n queryNode)
+	walk = func(n queryNode) {
+		switch n := n.(type) {
+		case andNode:
+			walk(n.left)
+			walk(n.right)
+		case orNode:
+			walk(n.left)
+			walk(n.right)
+		case notNode:
+			walk(n.operand)
+		case sectionNode:
+			sections = append(sections, n.section)
+		}
+	}
+	walk(q.root)
+	return sections
+}
+
+// Match reports whether an item in section with the given tags matches
+func (q *Query) Match(section string, tags []string) bool {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return q.root.match(queryItem{section: section, tags: set})
+}
+
+// FilterByQuery returns the IDs of items matching q. Bullets inherit the
+// tags of their entry and position, and coursework the tags of its
+// education entry, so a query can exclude e.g. everything from an internship.
+// Aliases and implied tags from the taxonomy are taken into account.
+func (r *Resume) FilterByQuery(q *Query) map[string]bool {
+	root := canonicalQuery(q.root, r.Taxonomy)
+	selectedIDs := make(map[string]bool)
+	for _, item := range r.queryItems() {
+		if root.match(item) {
+			selectedIDs[item.id] = true
+		}
+	}
+	return selectedIDs
+}
+
+// canonicalQuery returns n with every tag replaced by its canonical form
+func canonicalQuery(n queryNode, t *Taxonomy) queryNode {
+	switch n := n.(type) {
+	case andNode:
+		return andNode{canonicalQuery(n.left, t), canonicalQuery(n.right, t)}
+	case orNode:
+		return orNode{canonicalQuery(n.left, t), canonicalQuery(n.right, t)}
+	case notNode:
+		return notNode{canonicalQuery(n.operand, t)}
+	case tagNode:
+		return tagNode{t.Canonical(n.tag)}
+	}
+	return n
+}
+
+// ValidateQuery checks that every section a query scopes to exists
+func (r *Resume) ValidateQuery(q *Query) error {
+	available := r.SectionNames()
+	for _, section := range q.Sections() {
+		found := false
+		for _, name := range available {
+			if name == section {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("query: unknown section %q (available: %s)", section, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// queryItems lists every selectable item with its section and effective tags
+func (r *Resume) queryItems() []queryItem {
+	var items []queryItem
+	add := func(id, section string, tagLists ...[]string) {
+		var all []string
+		for _, list := range tagLists {
+			all = append(all, list...)
+		}
+		tags := make(map[string]bool)
+		for _, tag := range r.Taxonomy.Expand(all) {
+			tags[tag] = true
+		}
+		items = append(items, queryItem{id: id, section: section, tags: tags})
+	}
+
+	for _, exp := range r.Experience {
+		for _, role := range exp.Roles() {
+			for _, bullet := range role.Bullets {
+				add(bullet.ID, SectionExperience, bullet.Tags, role.Tags, exp.Tags)
+			}
+		}
+	}
+	for _, proj := range r.Projects {
+		for _, bullet := range proj.Bullets {
+			add(bullet.ID, SectionProjects, bullet.Tags, proj.Tags)
+		}
+	}
+	for _, lead := range r.Leadership {
+		add(lead.ID, SectionLeadership, lead.Tags)
+	}
+	for _, edu := range r.Education {
+		add(edu.EntryID(), SectionEducation, edu.Tags)
+		for _, course := range edu.Coursework {
+			add(course.ID, SectionEducation, course.Tags, edu.Tags)
+		}
+	}
+	for _, skill := range r.Skills.All() {
+		add(skill.SkillID(), SectionSkills, skill.Tags)
+	}
+	for _, section := range r.Sections {
+		for _, item := range section.Items {
+			add(item.ID, section.ID, item.Tags)
+		}
+	}
+	return items
+}
+
+// queryToken is a lexical token of a query; kind is one of "(", ")",
+// "and", "or", "not" or "term"
+type queryToken struct {
+	kind string
+	text string
+	pos  int
+}
+
+func tokenizeQuery(s string) []queryToken {
+	var tokens []queryToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, queryToken{kind: string(c), text: string(c), pos: i + 1})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				i++
+			}
+			word := string(runes[start:i])
+			kind := "term"
+			switch strings.ToLower(word) {
+			case "and", "or", "not":
+				kind = strings.ToLower(word)
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: word, pos: start + 1})
+		}
+	}
+	return tokens
+}
+
+// queryParser is a recursive descent parser over query tokens
+type queryParser struct {
+	tokens []queryToken
+	next   int
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.next >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.next]
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == "or"; tok = p.peek() {
+		p.next++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind != "or" && tok.kind != ")"; tok = p.peek() {
+		if tok.kind == "and" {
+			p.next++
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	tok := p.peek()
+	if tok != nil && tok.kind == "not" {
+		p.next++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, fmt.Errorf("query: unexpected end of query")
+	}
+	p.next++
+
+	switch tok.kind {
+	case "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != ")" {
+			return nil, fmt.Errorf("query: missing \")\" for \"(\" at position %d", tok.pos)
+		}
+		p.next++
+		return node, nil
+	case "term":
+		return parseTerm(*tok)
+	}
+	return nil, fmt.Errorf("query: unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func parseTerm(tok queryToken) (queryNode, error) {
+	field, value, ok := strings.Cut(tok.text, ":")
+	if !ok {
+		return tagNode{tok.text}, nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("query: missing value after %q at position %d", field+":", tok.pos)
+	}
+	switch strings.ToLower(field) {
+	case "section":
+		return sectionNode{value}, nil
+	case "tag":
+		return tagNode{value}, nil
+	}
+	return nil, fmt.Errorf("query: unknown field %q at position %d (available: section, tag)", field, tok.pos)
+}
@@ -0,0 +1,110 @@
+package resume
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func queryTestResume() *Resume {
+	return &Resume{
+		Experience: []ExperienceEntry{{
+			ID:   "acme",
+			Tags: []string{"fintech"},
+			Positions: []Position{
+				{ID: "acme-senior", Bullets: []Bullet{
+					{ID: "s1", Tags: []string{"go", "kafka"}},
+					{ID: "s2", Tags: []string{"python"}},
+				}},
+				{ID: "acme-intern", Tags: []string{"internship"}, Bullets: []Bullet{
+					{ID: "i1", Tags: []string{"go"}},
+				}},
+			},
+		}},
+		Projects: []ProjectEntry{{ID: "proj", Tags: []string{"go"}, Bullets: []Bullet{
+			{ID: "p1", Tags: []string{"rust"}},
+		}}},
+		Sections: []CustomSection{{ID: "talks", Items: []CustomItem{
+			{ID: "t1", Tags: []string{"go"}},
+		}}},
+	}
+}
+
+func queryIDs(t *testing.T, r *Resume, s string) []string {
+	t.Helper()
+	q, err := ParseQuery(s)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) failed: %v", s, err)
+	}
+	var ids []string
+	for id := range r.FilterByQuery(q) {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestFilterByQuery(t *testing.T) {
+	r := queryTestResume()
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"go", []string{"i1", "p1", "s1", "t1"}},
+		{"go AND NOT internship", []string{"p1", "s1", "t1"}},
+		{"go not internship", []string{"p1", "s1", "t1"}},
+		{"python OR rust AND fintech", []string{"s2"}},
+		{"(python OR rust) AND go", []string{"p1"}},
+		{"fintech AND NOT (kafka OR internship)", []string{"s2"}},
+		{"go AND section:projects", []string{"p1"}},
+		{"section:talks", []string{"t1"}},
+		{"tag:kafka", []string{"s1"}},
+		{"NOT NOT kafka", []string{"s1"}},
+	}
+	for _, tt := range tests {
+		if got := queryIDs(t, r, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "query is empty"},
+		{"go AND", "unexpected end of query"},
+		{"(go OR rust", `missing ")"`},
+		{"go )", `unexpected ")" at position 4`},
+		{"OR go", `unexpected "OR" at position 1`},
+		{"section:", "missing value"},
+		{"company:acme", `unknown field "company"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseQuery(%q) error = %v, want %q", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	r := queryTestResume()
+	q, err := ParseQuery("go AND (section:talks OR section:project)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateQuery(q); err == nil || !strings.Contains(err.Error(), `unknown section "project"`) {
+		t.Errorf("expected unknown section error, got %v", err)
+	}
+
+	q, err = ParseQuery("go AND section:talks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateQuery(q); err != nil {
+		t.Errorf("ValidateQuery failed: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layouts a custom section can be rendered with
+const (
+	// LayoutList renders each item's text as a bullet
+	LayoutList = "list"
+	// LayoutEntries renders dated entries with a title and subtitle
+	LayoutEntries = "entries"
+	// LayoutTable renders two-column key/value rows
+	LayoutTable = "table"
+)
+
+// Layouts lists the supported custom section layouts
+var Layouts = []string{LayoutList, LayoutEntries, LayoutTable}
+
+// CustomSection is a user-defined section such as certifications or awards
+type CustomSection struct {
+	ID     string       `yaml:"id" json:"id"`
+	Title  string       `yaml:"title" json:"title"`
+	Layout string       `yaml:"layout,omitempty" json:"layout"`
+	Items  []CustomItem `yaml:"items" json:"items"`
+}
+
+// CustomItem is one item of a custom section. Which fields are used depends
+// on the section layout: Text for lists, Title/Subtitle/Date (and optionally
+// Text) for entries, and Key/Value for tables.
+type CustomItem struct {
+	ID       string   `yaml:"id" json:"id"`
+	Text     string   `yaml:"text,omitempty" json:"text,omitempty"`
+	Title    string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Subtitle string   `yaml:"subtitle,omitempty" json:"subtitle,omitempty"`
+	Date     string   `yaml:"date,omitempty" json:"date,omitempty"`
+	Key      string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Value    string   `yaml:"value,omitempty" json:"value,omitempty"`
+	Tags     []string `yaml:"tags" json:"tags"`
+}
+
+// LayoutName returns the section layout, defaulting to LayoutList
+func (s CustomSection) LayoutName() string {
+	if s.Layout == "" {
+		return LayoutList
+	}
+	return s.Layout
+}
+
+// DisplayText returns a one-line description of the item for listings and prompts
+func (i CustomItem) DisplayText() string {
+	switch {
+	case i.Key != "":
+		return i.Key + ": " + i.Value
+	case i.Title != "":
+		text := i.Title
+		if i.Subtitle != "" {
+			text += ", " + i.Subtitle
+		}
+		if i.Date != "" {
+			text += " (" + i.Date + ")"
+		}
+		return text
+	}
+	return i.Text
+}
+
+// SectionNames returns every section of the resume in default order:
+// the built-in sections followed by custom sections in YAML order
+func (r *Resume) SectionNames() []string {
+	if r == nil || len(r.Sections) == 0 {
+		return DefaultSections
+	}
+	names := append([]string(nil), DefaultSections...)
+	for _, section := range r.Sections {
+		names = append(names, section.ID)
+	}
+	return names
+}
+
+// CustomSection returns the custom section with the given ID, or nil
+func (r *Resume) CustomSection(id string) *CustomSection {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Sections {
+		if r.Sections[i].ID == id {
+			return &r.Sections[i]
+		}
+	}
+	return nil
+}
+
+// SelectedItemIDs returns the section's item IDs present in selectedIDs, or
+// nil if the selection names none of them (meaning the section is not being
+// filtered)
+func (s CustomSection) SelectedItemIDs(selectedIDs map[string]bool) map[string]bool {
+	var itemIDs map[string]bool
+	for _, item := range s.Items {
+		if selectedIDs[item.ID] {
+			if itemIDs == nil {
+				itemIDs = make(map[string]bool)
+			}
+			itemIDs[item.ID] = true
+		}
+	}
+	return itemIDs
+}
+
+// validateSections checks custom section IDs, layouts and item IDs
+func (r *Resume) validateSections() error {
+	builtin := make(map[string]bool, len(DefaultSections))
+	for _, name := range DefaultSections {
+		builtin[name] = true
+	}
+	seen := make(map[string]bool, len(r.Sections))
+	for i, section := range r.Sections {
+		switch {
+		case section.ID == "":
+			return fmt.Errorf("sections[%d]: id is required", i)
+		case builtin[section.ID]:
+			return fmt.Errorf("section %q: id clashes with a built-in section", section.ID)
+		case seen[section.ID]:
+			return fmt.Errorf("section %q: id is used more than once", section.ID)
+		}
+		seen[section.ID] = true
+
+		if !isLayout(section.LayoutName()) {
+			return fmt.Errorf("section %q: unknown layout %q (available: %s)", section.ID, section.Layout, strings.Join(Layouts, ", "))
+		}
+		for j, item := range section.Items {
+			if item.ID == "" {
+				return fmt.Errorf("section %q: items[%d]: id is required", section.ID, j)
+			}
+		}
+	}
+	return nil
+}
+
+func isLayout(name string) bool {
+	for _, layout := range Layouts {
+		if layout == name {
+			return true
+		}
+	}
+	return false
+}
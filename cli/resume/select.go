@@ -0,0 +1,138 @@
+package resume
+
+// Select returns a copy of r holding only the items in selectedIDs, with
+// entries, positions, bullets and section items sorted by order (which may
+// be nil). An empty selection keeps everything. Roles, experience entries
+// and projects left without bullets are dropped, as are skill categories and
+// custom sections left empty. Skills, education and custom sections are
+// only filtered when the selection names one of their items, and an
+// education entry that is selected keeps all of its coursework unless some
+// of its courses are named.
+func (r *Resume) Select(selectedIDs map[string]bool, order *SectionOrder) *Resume {
+	includeAll := len(selectedIDs) == 0
+	sel := &Resume{
+		Contact:    r.Contact,
+		Summary:    r.Summary,
+		JSONResume: r.JSONResume,
+		Taxonomy:   r.Taxonomy,
+	}
+
+	// Copy entry slices so ordering never mutates r
+	education := append(Education(nil), r.Education...)
+	experience := append([]ExperienceEntry(nil), r.Experience...)
+	projects := append([]ProjectEntry(nil), r.Projects...)
+	leadership := append([]LeadershipEntry(nil), r.Leadership...)
+	if order != nil {
+		SortByOrder(education, order.Education, func(e EducationEntry) string { return e.EntryID() })
+		SortByOrder(experience, order.Experience, func(e ExperienceEntry) string { return e.ID })
+		SortByOrder(projects, order.Projects, func(p ProjectEntry) string { return p.ID })
+		SortByOrder(leadership, order.Leadership, func(l LeadershipEntry) string { return l.ID })
+	}
+
+	eduIDs := r.SelectedEducationIDs(selectedIDs)
+	for _, edu := range education {
+		entrySelected := eduIDs == nil || eduIDs[edu.EntryID()]
+		allCoursework := entrySelected
+		for _, course := range edu.Coursework {
+			if eduIDs[course.ID] {
+				allCoursework = false
+				break
+			}
+		}
+		var coursework []Bullet
+		for _, course := range orderedBullets(edu.Coursework, order.BulletOrder(edu.EntryID())) {
+			if allCoursework || eduIDs[course.ID] {
+				coursework = append(coursework, course)
+			}
+		}
+		if !entrySelected && len(coursework) == 0 {
+			continue
+		}
+		edu.Coursework = coursework
+		sel.Education = append(sel.Education, edu)
+	}
+
+	skillIDs := r.SelectedSkillIDs(selectedIDs)
+	for _, category := range r.Skills {
+		var items []SkillItem
+		for _, skill := range category.Items {
+			if skillIDs == nil || skillIDs[skill.SkillID()] {
+				items = append(items, skill)
+			}
+		}
+		if len(items) > 0 {
+			category.Items = items
+			sel.Skills = append(sel.Skills, category)
+		}
+	}
+
+	selectBullets := func(bullets []Bullet, orderIDs []string) []Bullet {
+		var kept []Bullet
+		for _, bullet := range orderedBullets(bullets, orderIDs) {
+			if includeAll || selectedIDs[bullet.ID] {
+				kept = append(kept, bullet)
+			}
+		}
+		return kept
+	}
+
+	for _, exp := range experience {
+		roles := append([]Position(nil), exp.Roles()...)
+		SortByOrder(roles, order.BulletOrder(exp.ID), func(p Position) string { return p.ID })
+		var kept []Position
+		for _, role := range roles {
+			if role.Bullets = selectBullets(role.Bullets, order.BulletOrder(role.ID)); len(role.Bullets) > 0 {
+				kept = append(kept, role)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		if len(exp.Positions) > 0 {
+			exp.Positions = kept
+		} else {
+			exp.Bullets = kept[0].Bullets
+		}
+		sel.Experience = append(sel.Experience, exp)
+	}
+
+	for _, proj := range projects {
+		if proj.Bullets = selectBullets(proj.Bullets, order.BulletOrder(proj.ID)); len(proj.Bullets) > 0 {
+			sel.Projects = append(sel.Projects, proj)
+		}
+	}
+
+	for _, lead := range leadership {
+		if includeAll || selectedIDs[lead.ID] {
+			sel.Leadership = append(sel.Leadership, lead)
+		}
+	}
+
+	for _, section := range r.Sections {
+		itemIDs := section.SelectedItemIDs(selectedIDs)
+		items := append([]CustomItem(nil), section.Items...)
+		SortByOrder(items, order.BulletOrder(section.ID), func(i CustomItem) string { return i.ID })
+		var kept []CustomItem
+		for _, item := range items {
+			if itemIDs == nil || itemIDs[item.ID] {
+				kept = append(kept, item)
+			}
+		}
+		if len(kept) > 0 {
+			section.Items = kept
+			sel.Sections = append(sel.Sections, section)
+		}
+	}
+
+	return sel
+}
+
+// orderedBullets returns a sorted copy of bullets, leaving the original untouched
+func orderedBullets(bullets []Bullet, orderIDs []string) []Bullet {
+	if len(orderIDs) == 0 {
+		return bullets
+	}
+	sorted := append([]Bullet(nil), bullets...)
+	SortByOrder(sorted, orderIDs, func(b Bullet) string { return b.ID })
+	return sorted
+}
@@ -0,0 +1,142 @@
+package resume
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Skills is an ordered list of skill categories.
+//
+// In YAML it is written as a list of categories:
+//
+//	skills:
+//	  - name: languages
+//	    label: Languages
+//	    items:
+//	      - name: Go
+//
+// The older mapping form (category key -> list of skills) is still accepted
+// and keeps the key order of the file.
+type Skills []SkillCategory
+
+// SkillCategory is a named group of skills
+type SkillCategory struct {
+	Name  string      `yaml:"name" json:"name"`
+	Label string      `yaml:"label,omitempty" json:"label,omitempty"`
+	Items []SkillItem `yaml:"items" json:"items"`
+}
+
+// SkillItem represents a single skill with tags
+type SkillItem struct {
+	ID   string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Name string   `yaml:"name" json:"name"`
+	Tags []string `yaml:"tags" json:"tags"`
+}
+
+// legacyCategoryLabels are display labels for the original fixed categories
+var legacyCategoryLabels = map[string]string{
+	"languages":  "Languages",
+	"frameworks": "Frameworks & Tools",
+	"cloud":      "Cloud",
+}
+
+// UnmarshalYAML accepts both the category list and the legacy mapping form
+func (s *Skills) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var categories []SkillCategory
+		if err := node.Decode(&categories); err != nil {
+			return err
+		}
+		*s = categories
+		return nil
+	case yaml.MappingNode:
+		var categories []SkillCategory
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var items []SkillItem
+			if err := node.Content[i+1].Decode(&items); err != nil {
+				return err
+			}
+			categories = append(categories, SkillCategory{
+				Name:  node.Content[i].Value,
+				Items: items,
+			})
+		}
+		*s = categories
+		return nil
+	}
+	return fmt.Errorf("line %d: skills must be a list of categories or a mapping", node.Line)
+}
+
+// DisplayLabel returns the category's label, deriving one from its name if unset
+func (c SkillCategory) DisplayLabel() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	if label, ok := legacyCategoryLabels[c.Name]; ok {
+		return label
+	}
+	words := strings.FieldsFunc(c.Name, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	})
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToTitle(first)) + word[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// SkillID returns the skill's ID, falling back to "skill-<slug>" of its name
+func (s SkillItem) SkillID() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return "skill-" + strings.ToLower(strings.ReplaceAll(s.Name, " ", "-"))
+}
+
+// All returns every skill across all categories
+func (s Skills) All() []SkillItem {
+	var all []SkillItem
+	for _, category := range s {
+		all = append(all, category.Items...)
+	}
+	return all
+}
+
+// ResolveIDs maps skill IDs or names to skill IDs, skipping unknown keys.
+// Names are accepted for selections written before skills had IDs.
+func (s Skills) ResolveIDs(keys []string) []string {
+	byKey := make(map[string]string)
+	for _, skill := range s.All() {
+		byKey[skill.SkillID()] = skill.SkillID()
+		byKey[skill.Name] = skill.SkillID()
+	}
+
+	var ids []string
+	for _, key := range keys {
+		if id, ok := byKey[key]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SelectedSkillIDs returns the skill IDs present in selectedIDs, or nil if the
+// selection names no skills at all (meaning skills are not being filtered)
+func (r *Resume) SelectedSkillIDs(selectedIDs map[string]bool) map[string]bool {
+	var skillIDs map[string]bool
+	for _, skill := range r.Skills.All() {
+		id := skill.SkillID()
+		if selectedIDs[id] {
+			if skillIDs == nil {
+				skillIDs = make(map[string]bool)
+			}
+			skillIDs[id] = true
+		}
+	}
+	return skillIDs
+}
@@ -0,0 +1,271 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TagDef declares a canonical tag in tags.yaml
+type TagDef struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Aliases are alternate spellings that mean this tag, e.g. k8s for kubernetes
+	Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// Implies lists tags every item with this tag also carries, e.g. lambda
+	// implies aws and serverless
+	Implies []string `yaml:"implies,omitempty" json:"implies,omitempty"`
+}
+
+// Taxonomy is the optional tag vocabulary loaded from tags.yaml. A nil
+// Taxonomy treats every tag as canonical with no implications.
+type Taxonomy struct {
+	Tags map[string]*TagDef
+	// canonical maps every canonical tag and alias to its canonical tag
+	canonical map[string]string
+}
+
+// TaxonomyPath returns the location of tags.yaml for the given resume file
+func TaxonomyPath(resumePath string) string {
+	return filepath.Join(filepath.Dir(resumePath), "tags.yaml")
+}
+
+// LoadTaxonomy reads tags.yaml, returning nil if it does not exist
+func LoadTaxonomy(taxonomyPath string) (*Taxonomy, error) {
+	data, err := os.ReadFile(taxonomyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tags := make(map[string]*TagDef)
+	if err := yaml.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("%s: %w", taxonomyPath, err)
+	}
+	t, err := NewTaxonomy(tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", taxonomyPath, err)
+	}
+	return t, nil
+}
+
+// NewTaxonomy builds a taxonomy from canonical tag definitions. An alias may
+// not name another canonical tag or belong to two tags, and implied tags must
+// be declared.
+func NewTaxonomy(tags map[string]*TagDef) (*Taxonomy, error) {
+	t := &Taxonomy{Tags: tags, canonical: make(map[string]string)}
+	for name, def := range tags {
+		if def == nil {
+			tags[name] = &TagDef{}
+		}
+		t.canonical[name] = name
+	}
+	for _, name := range t.Names() {
+		for _, alias := range tags[name].Aliases {
+			if other, ok := t.canonical[alias]; ok {
+				if other == alias {
+					return nil, fmt.Errorf("alias %q of tag %q is itself a tag", alias, name)
+				}
+				return nil, fmt.Errorf("alias %q belongs to both %q and %q", alias, other, name)
+			}
+			t.canonical[alias] = name
+		}
+	}
+	for _, name := range t.Names() {
+		for _, implied := range tags[name].Implies {
+			if _, ok := t.canonical[implied]; !ok {
+				return nil, fmt.Errorf("tag %q implies undeclared tag %q", name, implied)
+			}
+		}
+	}
+	return t, nil
+}
+
+// Names returns the canonical tags in sorted order
+func (t *Taxonomy) Names() []string {
+	if t == nil {
+		return nil
+	}
+	names := make([]string, 0, len(t.Tags))
+	for name := range t.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Known reports whether tag is a canonical tag or alias
+func (t *Taxonomy) Known(tag string) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.canonical[tag]
+	return ok
+}
+
+// Lookup returns the definition of tag or its canonical tag, or nil if the
+// tag is not declared
+func (t *Taxonomy) Lookup(tag string) *TagDef {
+	if t == nil {
+		return nil
+	}
+	return t.Tags[t.Canonical(tag)]
+}
+
+// Canonical returns the canonical form of tag, or tag itself if it is not
+// an alias
+func (t *Taxonomy) Canonical(tag string) string {
+	if t == nil {
+		return tag
+	}
+	if name, ok := t.canonical[tag]; ok {
+		return name
+	}
+	return tag
+}
+
+// CanonicalTags returns tags with aliases replaced by their canonical tags
+func (t *Taxonomy) CanonicalTags(tags []string) []string {
+	if t == nil {
+		return tags
+	}
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = t.Canonical(tag)
+	}
+	return result
+}
+
+// Expand returns the effective tags of an item: tags in canonical form
+// followed by everything they imply, without duplicates
+func (t *Taxonomy) Expand(tags []string) []string {
+	if t == nil {
+		return tags
+	}
+	var result []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		if tag = t.Canonical(tag); !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range tags {
+		add(tag)
+	}
+	// result grows as implied tags are added, so implications chain
+	for i := 0; i < len(result); i++ {
+		if def := t.Lookup(result[i]); def != nil {
+			for _, implied := range def.Implies {
+				add(implied)
+			}
+		}
+	}
+	return result
+}
+
+// TagUsage counts how many items carry each tag as written in the resume,
+// keyed by canonical tag
+func (r *Resume) TagUsage() map[string]int {
+	usage := make(map[string]int)
+	count := func(tags []string) {
+		seen := make(map[string]bool)
+		for _, tag := range r.Taxonomy.CanonicalTags(tags) {
+			if !seen[tag] {
+				seen[tag] = true
+				usage[tag]++
+			}
+		}
+	}
+
+	for _, exp := range r.Experience {
+		count(exp.Tags)
+		for _, role := range exp.Roles() {
+			if role.ID != exp.ID {
+				count(role.Tags)
+			}
+			for _, bullet := range role.Bullets {
+				count(bullet.Tags)
+			}
+		}
+	}
+	for _, proj := range r.Projects {
+		count(proj.Tags)
+		for _, bullet := range proj.Bullets {
+			count(bullet.Tags)
+		}
+	}
+	for _, lead := range r.Leadership {
+		count(lead.Tags)
+	}
+	for _, edu := range r.Education {
+		count(edu.Tags)
+		for _, course := range edu.Coursework {
+			count(course.Tags)
+		}
+	}
+	for _, skill := range r.Skills.All() {
+		count(skill.Tags)
+	}
+	for _, section := range r.Sections {
+		for _, item := range section.Items {
+			count(item.Tags)
+		}
+	}
+	return usage
+}
+
+// NearDuplicateTags returns pairs of distinct tags that probably mean the
+// same thing: spellings one edit apart, the same words with different
+// separators or plurals, or an acronym of a hyphenated tag (iac and
+// infrastructure-as-code). Each pair is sorted, and pairs are sorted.
+func NearDuplicateTags(tags []string) [][2]string {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+
+	var pairs [][2]string
+	for i, a := range sorted {
+		for _, b := range sorted[i+1:] {
+			if a != b && similarTags(a, b) {
+				pairs = append(pairs, [2]string{a, b})
+			}
+		}
+	}
+	return pairs
+}
+
+func similarTags(a, b string) bool {
+	if normalizeTag(a) == normalizeTag(b) {
+		return true
+	}
+	if len(a) >= 5 && len(b) >= 5 && editDistance(a, b) <= 1 {
+		return true
+	}
+	return acronym(a) == b || acronym(b) == a
+}
+
+// normalizeTag drops separators and a trailing plural s
+func normalizeTag(tag string) string {
+	tag = strings.NewReplacer("-", "", "_", "", " ", "").Replace(tag)
+	return strings.TrimSuffix(tag, "s")
+}
+
+// acronym returns the initials of a hyphenated tag, or "" for single words
+func acronym(tag string) string {
+	words := strings.Split(tag, "-")
+	if len(words) < 2 {
+		return ""
+	}
+	var initials strings.Builder
+	for _, word := range words {
+		if word != "" {
+			initials.WriteByte(word[0])
+		}
+	}
+	return initials.String()
+}
@@ -0,0 +1,130 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testTaxonomy(t *testing.T) *Taxonomy {
+	t.Helper()
+	tax, err := NewTaxonomy(map[string]*TagDef{
+		"aws":                    nil,
+		"serverless":             nil,
+		"containers":             nil,
+		"infrastructure-as-code": {Aliases: []string{"iac"}},
+		"kubernetes":             {Aliases: []string{"k8s"}, Implies: []string{"containers"}},
+		"lambda":                 {Implies: []string{"aws", "serverless"}},
+	})
+	if err != nil {
+		t.Fatalf("NewTaxonomy failed: %v", err)
+	}
+	return tax
+}
+
+func TestTaxonomyExpand(t *testing.T) {
+	tax := testTaxonomy(t)
+	if got := tax.Canonical("k8s"); got != "kubernetes" {
+		t.Errorf("Canonical(k8s) = %q", got)
+	}
+	got := tax.Expand([]string{"lambda", "iac", "go", "aws"})
+	want := []string{"lambda", "infrastructure-as-code", "go", "aws", "serverless"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand = %v, want %v", got, want)
+	}
+
+	var none *Taxonomy
+	if got := none.Expand([]string{"k8s"}); !reflect.DeepEqual(got, []string{"k8s"}) {
+		t.Errorf("nil Expand = %v", got)
+	}
+}
+
+func TestNewTaxonomyErrors(t *testing.T) {
+	tests := []struct {
+		tags map[string]*TagDef
+		want string
+	}{
+		{map[string]*TagDef{"aws": nil, "cloud": {Aliases: []string{"aws"}}}, `alias "aws" of tag "cloud" is itself a tag`},
+		{map[string]*TagDef{"a": {Aliases: []string{"x"}}, "b": {Aliases: []string{"x"}}}, `alias "x" belongs to both "a" and "b"`},
+		{map[string]*TagDef{"lambda": {Implies: []string{"aws"}}}, `implies undeclared tag "aws"`},
+	}
+	for _, tt := range tests {
+		if _, err := NewTaxonomy(tt.tags); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewTaxonomy error = %v, want %q", err, tt.want)
+		}
+	}
+}
+
+func TestFilterWithTaxonomy(t *testing.T) {
+	r := &Resume{
+		Taxonomy: testTaxonomy(t),
+		Projects: []ProjectEntry{{ID: "proj", Bullets: []Bullet{
+			{ID: "b1", Tags: []string{"k8s"}},
+			{ID: "b2", Tags: []string{"lambda"}},
+			{ID: "b3", Tags: []string{"iac"}},
+		}}},
+	}
+
+	if got := sortedIDs(r.FilterByTags([]string{"kubernetes"})); !reflect.DeepEqual(got, []string{"b1"}) {
+		t.Errorf("FilterByTags(kubernetes) = %v", got)
+	}
+	if got := sortedIDs(r.FilterByTags([]string{"containers", "aws"})); !reflect.DeepEqual(got, []string{"b1", "b2"}) {
+		t.Errorf("FilterByTags(containers, aws) = %v", got)
+	}
+	if got := queryIDs(t, r, "infrastructure-as-code OR (serverless AND NOT k8s)"); !reflect.DeepEqual(got, []string{"b2", "b3"}) {
+		t.Errorf("FilterByQuery = %v", got)
+	}
+
+	usage := r.TagUsage()
+	if usage["infrastructure-as-code"] != 1 || usage["iac"] != 0 || usage["aws"] != 0 {
+		t.Errorf("TagUsage = %v", usage)
+	}
+}
+
+func TestNearDuplicateTags(t *testing.T) {
+	got := NearDuplicateTags([]string{"iac", "infrastructure-as-code", "microservice", "microservices", "go", "gcp", "rest-api", "restapi"})
+	want := [][2]string{
+		{"iac", "infrastructure-as-code"},
+		{"microservice", "microservices"},
+		{"rest-api", "restapi"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NearDuplicateTags = %v, want %v", got, want)
+	}
+}
+
+func TestLoadResumeTaxonomy(t *testing.T) {
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.yaml")
+	if err := os.WriteFile(resumePath, []byte("contact:\n  name: Test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadResume(resumePath)
+	if err != nil {
+		t.Fatalf("LoadResume failed: %v", err)
+	}
+	if r.Taxonomy != nil {
+		t.Errorf("expected no taxonomy without tags.yaml")
+	}
+
+	if err := os.WriteFile(TaxonomyPath(resumePath), []byte("kubernetes:\n  aliases: [k8s]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err = LoadResume(resumePath)
+	if err != nil {
+		t.Fatalf("LoadResume failed: %v", err)
+	}
+	if got := r.Taxonomy.Canonical("k8s"); got != "kubernetes" {
+		t.Errorf("Canonical(k8s) = %q", got)
+	}
+
+	if err := os.WriteFile(TaxonomyPath(resumePath), []byte("a:\n  implies: [b]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadResume(resumePath); err == nil || !strings.Contains(err.Error(), "tags.yaml") {
+		t.Errorf("expected tags.yaml error, got %v", err)
+	}
+}
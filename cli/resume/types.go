@@ -1,14 +1,36 @@
 package resume
 
+import (
+	"strings"
+	"time"
+)
+
 // Resume represents the complete resume data structure
 type Resume struct {
-	Contact    ContactInfo         `yaml:"contact"`
-	Summary    string              `yaml:"summary" json:"summary"`
-	Education  EducationEntry      `yaml:"education"`
-	Skills     Skills              `yaml:"skills"`
-	Experience []ExperienceEntry   `yaml:"experience"`
-	Projects   []ProjectEntry      `yaml:"projects"`
-	Leadership []LeadershipEntry   `yaml:"leadership"`
+	Contact    ContactInfo       `yaml:"contact"`
+	Summary    string            `yaml:"summary" json:"summary"`
+	Education  Education         `yaml:"education"`
+	Skills     Skills            `yaml:"skills"`
+	Experience []ExperienceEntry `yaml:"experience"`
+	Projects   []ProjectEntry    `yaml:"projects"`
+	Leadership []LeadershipEntry `yaml:"leadership"`
+	// Sections holds user-defined sections such as certifications or awards
+	Sections []CustomSection `yaml:"sections,omitempty"`
+	// JSONResume keeps the fields of an imported JSON Resume that have no
+	// counterpart here, so exporting it again loses nothing
+	JSONResume *JSONResumeExtras `yaml:"jsonresume,omitempty"`
+	// Taxonomy is loaded from tags.yaml next to the resume, if present
+	Taxonomy *Taxonomy `yaml:"-"`
+}
+
+// JSONResumeExtras holds JSON Resume fields with no counterpart in this
+// schema. Items is keyed by the ID of the entry the fields belong to, or
+// "skills/<name>" for a skill category.
+type JSONResumeExtras struct {
+	// Root holds top-level fields such as meta
+	Root   map[string]any            `yaml:"root,omitempty"`
+	Basics map[string]any            `yaml:"basics,omitempty"`
+	Items  map[string]map[string]any `yaml:"items,omitempty"`
 }
 
 // ContactInfo holds personal contact information
@@ -21,39 +43,73 @@ type ContactInfo struct {
 	GitHub   string `yaml:"github" json:"github"`
 }
 
-// EducationEntry represents education details
-type EducationEntry struct {
-	Institution string `yaml:"institution" json:"institution"`
-	Location    string `yaml:"location" json:"location"`
-	Degree      string `yaml:"degree" json:"degree"`
-	Minor       string `yaml:"minor" json:"minor"`
-	GPA         string `yaml:"gpa" json:"gpa"`
-	Honors      string `yaml:"honors" json:"honors"`
+// ExperienceEntry represents a work experience. An entry holds either a
+// single role (Title, dates and Bullets) or, for promotions and repeat
+// stints at one company, a list of Positions.
+type ExperienceEntry struct {
+	ID        string     `yaml:"id"`
+	Title     string     `yaml:"title"`
+	Company   string     `yaml:"company"`
+	Location  string     `yaml:"location"`
+	StartDate Date       `yaml:"start_date"`
+	EndDate   Date       `yaml:"end_date"`
+	Tags      []string   `yaml:"tags"`
+	Bullets   []Bullet   `yaml:"bullets"`
+	Positions []Position `yaml:"positions,omitempty"`
 }
 
-// Skills contains all skill categories
-type Skills struct {
-	Languages  []SkillItem `yaml:"languages" json:"languages"`
-	Frameworks []SkillItem `yaml:"frameworks" json:"frameworks"`
-	Cloud      []SkillItem `yaml:"cloud" json:"cloud"`
+// Position is one role held within an experience entry
+type Position struct {
+	ID    string `yaml:"id"`
+	Title string `yaml:"title"`
+	// Location overrides the entry's location when set
+	Location  string   `yaml:"location,omitempty"`
+	StartDate Date     `yaml:"start_date"`
+	EndDate   Date     `yaml:"end_date"`
+	Tags      []string `yaml:"tags"`
+	Bullets   []Bullet `yaml:"bullets"`
 }
 
-// SkillItem represents a single skill with tags
-type SkillItem struct {
-	Name string   `yaml:"name" json:"name"`
-	Tags []string `yaml:"tags" json:"tags"`
+// Roles returns the entry's positions, or the entry itself as a single
+// position (sharing the entry's ID) when it has none
+func (e ExperienceEntry) Roles() []Position {
+	if len(e.Positions) > 0 {
+		return e.Positions
+	}
+	return []Position{{
+		ID:        e.ID,
+		Title:     e.Title,
+		StartDate: e.StartDate,
+		EndDate:   e.EndDate,
+		Tags:      e.Tags,
+		Bullets:   e.Bullets,
+	}}
 }
 
-// ExperienceEntry represents a work experience
-type ExperienceEntry struct {
-	ID        string   `yaml:"id"`
-	Title     string   `yaml:"title"`
-	Company   string   `yaml:"company"`
-	Location  string   `yaml:"location"`
-	StartDate string   `yaml:"start_date"`
-	EndDate   string   `yaml:"end_date"`
-	Tags      []string `yaml:"tags"`
-	Bullets   []Bullet `yaml:"bullets"`
+// Span returns the earliest start and latest end date across the entry's roles
+func (e ExperienceEntry) Span(now time.Time) (start, end Date) {
+	return PositionSpan(e.Roles(), now)
+}
+
+// PositionSpan returns the earliest start and latest end date of roles
+func PositionSpan(roles []Position, now time.Time) (start, end Date) {
+	for i, role := range roles {
+		if i == 0 || role.StartDate.Before(start, now) {
+			start = role.StartDate
+		}
+		if i == 0 || end.Before(role.EndDate, now) {
+			end = role.EndDate
+		}
+	}
+	return start, end
+}
+
+// RoleCategory labels a role for listings as "Company - Title"
+func (e ExperienceEntry) RoleCategory(role Position) string {
+	if role.Title == "" {
+		return e.Company
+	}
+	return e.Company + " - " + role.Title
 }
 
 // ProjectEntry represents a project
@@ -71,6 +127,8 @@ type Bullet struct {
 	ID   string   `yaml:"id"`
 	Text string   `yaml:"text"`
 	Tags []string `yaml:"tags"`
+	// Variants holds alternate wordings keyed by variant name, such as "short"
+	Variants map[string]string `yaml:"variants,omitempty"`
 }
 
 // LeadershipEntry represents a leadership activity
@@ -84,16 +142,18 @@ type LeadershipEntry struct {
 func (r *Resume) GetAllIDs() []ItemWithID {
 	var items []ItemWithID
 
-	// Experience bullets
+	// Experience bullets, grouped by role
 	for _, exp := range r.Experience {
-		for _, bullet := range exp.Bullets {
-			items = append(items, ItemWithID{
-				ID:       bullet.ID,
-				Text:     bullet.Text,
-				Tags:     bullet.Tags,
-				Section:  "Experience",
-				Category: exp.Company,
-			})
+		for _, role := range exp.Roles() {
+			for _, bullet := range role.Bullets {
+				items = append(items, ItemWithID{
+					ID:       bullet.ID,
+					Text:     bullet.Text,
+					Tags:     r.Taxonomy.Expand(bullet.Tags),
+					Section:  "Experience",
+					Category: exp.RoleCategory(role),
+				})
+			}
 		}
 	}
 
@@ -103,7 +163,7 @@ func (r *Resume) GetAllIDs() []ItemWithID {
 			items = append(items, ItemWithID{
 				ID:       bullet.ID,
 				Text:     bullet.Text,
-				Tags:     bullet.Tags,
+				Tags:     r.Taxonomy.Expand(bullet.Tags),
 				Section:  "Projects",
 				Category: proj.Title,
 			})
@@ -115,12 +175,58 @@ func (r *Resume) GetAllIDs() []ItemWithID {
 		items = append(items, ItemWithID{
 			ID:       lead.ID,
 			Text:     lead.Text,
-			Tags:     lead.Tags,
+			Tags:     r.Taxonomy.Expand(lead.Tags),
 			Section:  "Leadership",
 			Category: "",
 		})
 	}
 
+	// Education entries and coursework
+	for _, edu := range r.Education {
+		items = append(items, ItemWithID{
+			ID:       edu.EntryID(),
+			Text:     strings.TrimSpace(edu.Degree + ", " + edu.Institution),
+			Tags:     r.Taxonomy.Expand(edu.Tags),
+			Section:  "Education",
+			Category: edu.Institution,
+		})
+		for _, course := range edu.Coursework {
+			items = append(items, ItemWithID{
+				ID:       course.ID,
+				Text:     course.Text,
+				Tags:     r.Taxonomy.Expand(course.Tags),
+				Section:  "Education",
+				Category: edu.Institution,
+			})
+		}
+	}
+
+	// Skills
+	for _, category := range r.Skills {
+		for _, skill := range category.Items {
+			items = append(items, ItemWithID{
+				ID:       skill.SkillID(),
+				Text:     skill.Name,
+				Tags:     r.Taxonomy.Expand(skill.Tags),
+				Section:  "Skills",
+				Category: category.DisplayLabel(),
+			})
+		}
+	}
+
+	// Custom section items
+	for _, section := range r.Sections {
+		for _, item := range section.Items {
+			items = append(items, ItemWithID{
+				ID:       item.ID,
+				Text:     item.DisplayText(),
+				Tags:     r.Taxonomy.Expand(item.Tags),
+				Section:  section.Title,
+				Category: "",
+			})
+		}
+	}
+
 	return items
 }
 
@@ -133,30 +239,30 @@ type ItemWithID struct {
 	Category string
 }
 
-// FilterByIDs returns only items matching the given IDs
+// FilterByIDs returns the IDs selected by ids, expanding parents to their
+// children and applying "-id" exclusions (see Index.Expand)
 func (r *Resume) FilterByIDs(ids []string) map[string]bool {
-	idSet := make(map[string]bool)
-	for _, id := range ids {
-		idSet[id] = true
-	}
-	return idSet
+	return r.Index().Expand(ids)
 }
 
-// FilterByTags returns IDs matching any of the given tags
+// FilterByTags returns IDs matching any of the given tags. Aliases and
+// implied tags from the taxonomy are taken into account.
 func (r *Resume) FilterByTags(tags []string) map[string]bool {
 	tagSet := make(map[string]bool)
-	for _, tag := range tags {
+	for _, tag := range r.Taxonomy.CanonicalTags(tags) {
 		tagSet[tag] = true
 	}
 
 	selectedIDs := make(map[string]bool)
 
 	for _, exp := range r.Experience {
-		for _, bullet := range exp.Bullets {
-			for _, tag := range bullet.Tags {
-				if tagSet[tag] {
-					selectedIDs[bullet.ID] = true
-					break
+		for _, role := range exp.Roles() {
+			for _, bullet := range role.Bullets {
+				for _, tag := range r.Taxonomy.Expand(bullet.Tags) {
+					if tagSet[tag] {
+						selectedIDs[bullet.ID] = true
+						break
+					}
 				}
 			}
 		}
@@ -164,7 +270,7 @@ func (r *Resume) FilterByTags(tags []string) map[string]bool {
 
 	for _, proj := range r.Projects {
 		for _, bullet := range proj.Bullets {
-			for _, tag := range bullet.Tags {
+			for _, tag := range r.Taxonomy.Expand(bullet.Tags) {
 				if tagSet[tag] {
 					selectedIDs[bullet.ID] = true
 					break
@@ -174,7 +280,7 @@ func (r *Resume) FilterByTags(tags []string) map[string]bool {
 	}
 
 	for _, lead := range r.Leadership {
-		for _, tag := range lead.Tags {
+		for _, tag := range r.Taxonomy.Expand(lead.Tags) {
 			if tagSet[tag] {
 				selectedIDs[lead.ID] = true
 				break
@@ -182,5 +288,42 @@ func (r *Resume) FilterByTags(tags []string) map[string]bool {
 		}
 	}
 
+	for _, edu := range r.Education {
+		for _, tag := range r.Taxonomy.Expand(edu.Tags) {
+			if tagSet[tag] {
+				selectedIDs[edu.EntryID()] = true
+				break
+			}
+		}
+		for _, course := range edu.Coursework {
+			for _, tag := range r.Taxonomy.Expand(course.Tags) {
+				if tagSet[tag] {
+					selectedIDs[course.ID] = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, skill := range r.Skills.All() {
+		for _, tag := range r.Taxonomy.Expand(skill.Tags) {
+			if tagSet[tag] {
+				selectedIDs[skill.SkillID()] = true
+				break
+			}
+		}
+	}
+
+	for _, section := range r.Sections {
+		for _, item := range section.Items {
+			for _, tag := range r.Taxonomy.Expand(item.Tags) {
+				if tagSet[tag] {
+					selectedIDs[item.ID] = true
+					break
+				}
+			}
+		}
+	}
+
 	return selectedIDs
 }
@@ -9,3 +9,118 @@ func TestResumeTypes(t *testing.T) {
 		t.Fatal("Resume should not be nil")
 	}
 }
+
+func TestSkillID(t *testing.T) {
+	tests := []struct {
+		skill    SkillItem
+		expected string
+	}{
+		{SkillItem{Name: "Go"}, "skill-go"},
+		{SkillItem{Name: "Cloud Run"}, "skill-cloud-run"},
+		{SkillItem{ID: "aws-sa-cert", Name: "AWS Certified Solutions Architect"}, "aws-sa-cert"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.skill.SkillID(); got != tt.expected {
+			t.Errorf("SkillID(%+v) = %q, want %q", tt.skill, got, tt.expected)
+		}
+	}
+}
+
+func TestFilterByTagsIncludesSkills(t *testing.T) {
+	r := &Resume{
+		Skills: Skills{
+			{Name: "languages", Items: []SkillItem{{Name: "Go", Tags: []string{"go"}}}},
+			{Name: "frameworks", Items: []SkillItem{{Name: "React", Tags: []string{"frontend"}}}},
+		},
+		Experience: []ExperienceEntry{
+			{ID: "exp", Bullets: []Bullet{{ID: "b1", Tags: []string{"go"}}}},
+		},
+	}
+
+	selected := r.FilterByTags([]string{"go"})
+	if !selected["skill-go"] || !selected["b1"] {
+		t.Errorf("FilterByTags = %v, want skill-go and b1", selected)
+	}
+	if selected["skill-react"] {
+		t.Error("FilterByTags should not select untagged skills")
+	}
+
+	skillIDs := r.SelectedSkillIDs(selected)
+	if len(skillIDs) != 1 || !skillIDs["skill-go"] {
+		t.Errorf("SelectedSkillIDs = %v, want only skill-go", skillIDs)
+	}
+	if r.SelectedSkillIDs(map[string]bool{"b1": true}) != nil {
+		t.Error("SelectedSkillIDs should be nil when no skills are selected")
+	}
+}
+
+func TestExperiencePositions(t *testing.T) {
+	r := &Resume{
+		Experience: []ExperienceEntry{
+			{
+				ID:       "cap1",
+				Company:  "Capital One",
+				Location: "McLean, VA",
+				Tags:     []string{"fintech"},
+				Positions: []Position{
+					{ID: "cap1-swe", Title: "Software Engineer", Bullets: []Bullet{{ID: "b1", Tags: []string{"go"}}}},
+					{ID: "cap1-intern", Title: "Intern", Bullets: []Bullet{{ID: "b2", Tags: []string{"scala"}}}},
+				},
+			},
+			{ID: "solo", Title: "Founder", Company: "HashTop", Bullets: []Bullet{{ID: "b3"}}},
+		},
+	}
+
+	categories := make(map[string]string)
+	for _, item := range r.GetAllIDs() {
+		categories[item.ID] = item.Category
+	}
+	if categories["b1"] != "Capital One - Software Engineer" || categories["b2"] != "Capital One - Intern" {
+		t.Errorf("position categories = %q, %q", categories["b1"], categories["b2"])
+	}
+	if categories["b3"] != "HashTop - Founder" {
+		t.Errorf("single-role category = %q, want %q", categories["b3"], "HashTop - Founder")
+	}
+
+	selected := r.FilterByTags([]string{"scala"})
+	if !selected["b2"] || selected["b1"] {
+		t.Errorf("FilterByTags = %v, want only b2", selected)
+	}
+
+	roles := r.Experience[1].Roles()
+	if len(roles) != 1 || roles[0].ID != "solo" || roles[0].Title != "Founder" {
+		t.Errorf("Roles() of an entry without positions = %+v", roles)
+	}
+}
+
+func TestBulletVariants(t *testing.T) {
+	bullet := Bullet{ID: "b1", Text: "Long wording", Variants: map[string]string{"short": "Short"}}
+	if got := bullet.TextFor("short"); got != "Short" {
+		t.Errorf("TextFor(short) = %q, want %q", got, "Short")
+	}
+	for _, variant := range []string{"", "formal", VariantAuto} {
+		if got := bullet.TextFor(variant); got != "Long wording" {
+			t.Errorf("TextFor(%q) = %q, want the default text", variant, got)
+		}
+	}
+
+	r := &Resume{Projects: []ProjectEntry{{ID: "p", Bullets: []Bullet{bullet}}}}
+	if err := r.ValidateVariant("short"); err != nil {
+		t.Errorf("ValidateVariant(short) = %v", err)
+	}
+	if err := r.ValidateVariant(VariantAuto); err != nil {
+		t.Errorf("ValidateVariant(auto) = %v", err)
+	}
+	if err := r.ValidateVariant("shrot"); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+
+	ids, variants := SplitVariants([]string{"b1:short", "b2"})
+	if len(ids) != 2 || ids[0] != "b1" || ids[1] != "b2" {
+		t.Errorf("SplitVariants ids = %v", ids)
+	}
+	if len(variants) != 1 || variants["b1"] != "short" {
+		t.Errorf("SplitVariants variants = %v", variants)
+	}
+}
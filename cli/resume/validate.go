@@ -0,0 +1,431 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a single finding reported by Validate
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as file:line:column: message
+func (p Problem) String() string {
+	switch {
+	case p.Line == 0:
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// Validate strictly checks a resume file and, if they exist, its order file
+// and tags.yaml.
+// It reports unknown or mistyped fields, missing and duplicate IDs, and
+// order entries that reference IDs the resume does not define. An error is
+// returned only when a file cannot be read or is not valid YAML.
+func Validate(resumePath, orderPath string) ([]Problem, error) {
+	root, err := parseYAMLFile(resumePath)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{file: resumePath, ids: make(map[string]*yaml.Node)}
+	v.checkNode(root, reflect.TypeOf(Resume{}), "")
+
+	// Decode errors not already explained by the schema walk
+	var r Resume
+	if err := root.Decode(&r); err != nil && len(v.problems) == 0 {
+		v.add(nil, "%v", err)
+	}
+	if err := r.validateSections(); err != nil {
+		v.add(mappingValue(root, "sections"), "%v", err)
+	}
+	problems := v.sorted()
+
+	if orderPath != "" {
+		orderProblems, err := validateOrder(orderPath, &r)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, orderProblems...)
+	}
+
+	taxonomyPath := TaxonomyPath(resumePath)
+	if _, err := os.Stat(taxonomyPath); err == nil {
+		if _, err := LoadTaxonomy(taxonomyPath); err != nil {
+			message := strings.TrimPrefix(err.Error(), taxonomyPath+": ")
+			problems = append(problems, Problem{File: taxonomyPath, Message: message})
+		}
+	}
+
+	return problems, nil
+}
+
+// parseYAMLFile reads a file into its root content node
+func parseYAMLFile(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// validator walks a YAML tree against the Go types it decodes into
+type validator struct {
+	file     string
+	problems []Problem
+	// ids maps each item ID to the node that first defined it
+	ids map[string]*yaml.Node
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...any) {
+	p := Problem{File: v.file, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, p)
+}
+
+var (
+	educationType = reflect.TypeOf(Education{})
+	skillsType    = reflect.TypeOf(Skills{})
+	dateType      = reflect.TypeOf(Date{})
+)
+
+// checkNode reports fields and values in node that do not fit type t
+func (v *validator) checkNode(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if isNull(node) {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Types with their own UnmarshalYAML accept alternate forms
+	switch {
+	case t == dateType:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, "%s: expected a date", describe(path))
+		} else if _, err := ParseDate(node.Value); err != nil {
+			v.add(node, "%s: %v", describe(path), err)
+		}
+		return
+	case t == educationType && node.Kind == yaml.MappingNode:
+		v.checkNode(node, t.Elem(), path)
+		return
+	case t == skillsType && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			v.checkNode(node.Content[i+1], reflect.TypeOf([]SkillItem{}), join(path, key))
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		// Free-form data such as imported JSON Resume fields
+		return
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, "%s: expected a mapping", describe(path))
+			return
+		}
+		fields := yamlFields(t)
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if seen[key.Value] {
+				v.add(key, "duplicate field %q in %s", key.Value, describe(path))
+				continue
+			}
+			seen[key.Value] = true
+			field, ok := fields[key.Value]
+			if !ok {
+				v.add(key, "unknown field %q in %s", key.Value, describe(path))
+				continue
+			}
+			v.checkNode(value, field, join(path, key.Value))
+		}
+		v.checkID(node, t, path)
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, "%s: expected a list", describe(path))
+			return
+		}
+		for i, item := range node.Content {
+			v.checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, "%s: expected a mapping", describe(path))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkNode(node.Content[i+1], t.Elem(), join(path, node.Content[i].Value))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, "%s: expected a single value", describe(path))
+		}
+	}
+}
+
+// checkID records the ID of a selectable item and reports missing or duplicate IDs
+func (v *validator) checkID(node *yaml.Node, t reflect.Type, path string) {
+	ptr := reflect.New(t)
+	if err := node.Decode(ptr.Interface()); err != nil {
+		return
+	}
+
+	var id string
+	switch item := ptr.Interface().(type) {
+	case *ExperienceEntry:
+		id = item.ID
+		if len(item.Positions) > 0 && len(item.Bullets) > 0 {
+			v.add(mappingValue(node, "bullets"), "%s: bullets must be listed under positions when an entry has positions", describe(path))
+		}
+	case *Position:
+		id = item.ID
+	case *ProjectEntry:
+		id = item.ID
+	case *Bullet:
+		id = item.ID
+		if _, ok := item.Variants[VariantAuto]; ok {
+			v.add(mappingValue(node, "variants"), "%s: variant name %q is reserved", describe(path), VariantAuto)
+		}
+	case *LeadershipEntry:
+		id = item.ID
+	case *CustomItem:
+		// Missing IDs are reported by validateSections
+		id = item.ID
+		if id == "" {
+			return
+		}
+	case *EducationEntry:
+		id = item.EntryID()
+	case *SkillItem:
+		id = item.SkillID()
+	default:
+		return
+	}
+
+	if id == "" {
+		v.add(node, "%s: missing id", describe(path))
+		return
+	}
+	at := node
+	if idNode := mappingValue(node, "id"); idNode != nil {
+		at = idNode
+	}
+	if first, ok := v.ids[id]; ok {
+		v.add(at, "duplicate id %q (first defined at line %d)", id, first.Line)
+		return
+	}
+	v.ids[id] = at
+}
+
+// validateOrder checks an order file against the resume it orders
+func validateOrder(orderPath string, r *Resume) ([]Problem, error) {
+	if _, err := os.Stat(orderPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	root, err := parseYAMLFile(orderPath)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{file: orderPath, ids: make(map[string]*yaml.Node)}
+	v.checkNode(root, reflect.TypeOf(SectionOrder{}), "")
+	if root.Kind != yaml.MappingNode {
+		return v.problems, nil
+	}
+
+	entryIDs := func(ids ...string) map[string]bool {
+		set := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			set[id] = true
+		}
+		return set
+	}
+	var education, experience, projects, leadership []string
+	children := make(map[string]map[string]bool)
+	for _, edu := range r.Education {
+		education = append(education, edu.EntryID())
+		children[edu.EntryID()] = entryIDs(BulletIDs(edu.Coursework)...)
+	}
+	for _, exp := range r.Experience {
+		experience = append(experience, exp.ID)
+		var positions []string
+		for _, role := range exp.Roles() {
+			positions = append(positions, role.ID)
+			children[role.ID] = entryIDs(BulletIDs(role.Bullets)...)
+		}
+		if len(exp.Positions) > 0 {
+			children[exp.ID] = entryIDs(positions...)
+		}
+	}
+	for _, proj := range r.Projects {
+		projects = append(projects, proj.ID)
+		children[proj.ID] = entryIDs(BulletIDs(proj.Bullets)...)
+	}
+	for _, lead := range r.Leadership {
+		leadership = append(leadership, lead.ID)
+	}
+	for _, section := range r.Sections {
+		var items []string
+		for _, item := range section.Items {
+			items = append(items, item.ID)
+		}
+		children[section.ID] = entryIDs(items...)
+	}
+
+	known := map[string]map[string]bool{
+		"sections":   entryIDs(r.SectionNames()...),
+		"education":  entryIDs(education...),
+		"experience": entryIDs(experience...),
+		"projects":   entryIDs(projects...),
+		"leadership": entryIDs(leadership...),
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if key == "bullets" {
+			if value.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				entryNode, listNode := value.Content[j], value.Content[j+1]
+				childIDs, ok := children[entryNode.Value]
+				if !ok {
+					v.add(entryNode, "bullets: unknown entry %q", entryNode.Value)
+					continue
+				}
+				v.checkRefs(listNode, childIDs, "bullets."+entryNode.Value)
+			}
+			continue
+		}
+		if ids, ok := known[key]; ok {
+			v.checkRefs(value, ids, key)
+		}
+	}
+
+	return v.sorted(), nil
+}
+
+// sorted returns the problems in file order; IDs are checked after the
+// fields they contain, so the walk alone does not report in order
+func (v *validator) sorted() []Problem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems
+}
+
+// checkRefs reports entries of a sequence node that are not in ids
+func (v *validator) checkRefs(node *yaml.Node, ids map[string]bool, path string) {
+	if node.Kind != yaml.SequenceNode {
+		return
+	}
+	seen := make(map[string]bool, len(node.Content))
+	for _, item := range node.Content {
+		switch {
+		case !ids[item.Value]:
+			v.add(item, "%s: unknown id %q", path, item.Value)
+		case seen[item.Value]:
+			v.add(item, "%s: %q listed more than once", path, item.Value)
+		}
+		seen[item.Value] = true
+	}
+}
+
+// BulletIDs returns the IDs of bullets in order
+func BulletIDs(bullets []Bullet) []string {
+	ids := make([]string, len(bullets))
+	for i, bullet := range bullets {
+		ids[i] = bullet.ID
+	}
+	return ids
+}
+
+// yamlFields maps the YAML keys of a struct type to their field types
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			for key, ft := range yamlFields(f.Type) {
+				fields[key] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describe(path string) string {
+	if path == "" {
+		return "the top level"
+	}
+	return path
+}
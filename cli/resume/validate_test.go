@@ -0,0 +1,194 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeValidateFiles(t *testing.T, resumeYAML, orderYAML string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.yaml")
+	if err := os.WriteFile(resumePath, []byte(resumeYAML), 0644); err != nil {
+		t.Fatalf("failed to write resume: %v", err)
+	}
+	orderPath := OrderPath(resumePath)
+	if orderYAML != "" {
+		if err := os.WriteFile(orderPath, []byte(orderYAML), 0644); err != nil {
+			t.Fatalf("failed to write order: %v", err)
+		}
+	}
+	return resumePath, orderPath
+}
+
+func TestValidateValidResume(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, `contact:
+  name: Test User
+education:
+  institution: Test University
+skills:
+  languages:
+    - name: Go
+experience:
+  - id: exp-a
+    bullets:
+      - id: a1
+        text: First
+      - id: a2
+        text: Second
+`, `experience: [exp-a]
+bullets:
+  exp-a: [a2, a1]
+`)
+
+	problems, err := Validate(resumePath, orderPath)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateReportsPositions(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, `contact:
+  name: Test User
+experience:
+  - id: exp-a
+    bulets:
+      - id: a1
+  - id: exp-b
+    bullets:
+      - id: b1
+        text: First
+      - id: b1
+        text: Again
+      - text: No ID
+skills:
+  - name: languages
+    items:
+      - name: Go
+      - name: Go
+`, `experience: [exp-a, exp-missing]
+bullets:
+  exp-b: [b1, b9]
+  ghost: [x]
+sections: [summary, hobbies]
+`)
+
+	problems, err := Validate(resumePath, orderPath)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := []string{
+		`resume.yaml:5:5: unknown field "bulets" in experience[0]`,
+		`resume.yaml:11:13: duplicate id "b1" (first defined at line 9)`,
+		`resume.yaml:13:9: experience[1].bullets[2]: missing id`,
+		`resume.yaml:18:9: duplicate id "skill-go" (first defined at line 17)`,
+		`order.yaml:1:21: experience: unknown id "exp-missing"`,
+		`order.yaml:3:15: bullets.exp-b: unknown id "b9"`,
+		`order.yaml:4:3: bullets: unknown entry "ghost"`,
+		`order.yaml:5:21: sections: unknown id "hobbies"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(problems), len(want), problems)
+	}
+	for i, problem := range problems {
+		if got := problem.String(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("problem %d = %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
+
+func TestValidateCustomSections(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, `sections:
+  - id: awards
+    title: Awards
+    layout: grid
+    items:
+      - id: award-1
+        txt: Typo
+`, "")
+
+	problems, err := Validate(resumePath, orderPath)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %v", problems)
+	}
+	if !strings.Contains(problems[0].Message, `unknown layout "grid"`) {
+		t.Errorf("unexpected first problem: %v", problems[0])
+	}
+	if !strings.Contains(problems[1].Message, `unknown field "txt"`) {
+		t.Errorf("unexpected second problem: %v", problems[1])
+	}
+}
+
+func TestValidateExperiencePositions(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, `experience:
+  - id: cap1
+    company: Capital One
+    bullets:
+      - id: stray
+    positions:
+      - id: cap1-swe
+        title: Software Engineer
+        bullets:
+          - id: b1
+      - id: cap1-intern
+        title: Intern
+        bullets:
+          - id: b2
+`, `bullets:
+  cap1: [cap1-intern, b1]
+  cap1-swe: [b1]
+`)
+
+	problems, err := Validate(resumePath, orderPath)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := []string{
+		`resume.yaml:5:7: experience[0]: bullets must be listed under positions when an entry has positions`,
+		`order.yaml:2:23: bullets.cap1: unknown id "b1"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(problems), len(want), problems)
+	}
+	for i, problem := range problems {
+		if got := problem.String(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("problem %d = %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, "contact: [unclosed\n", "")
+	if _, err := Validate(resumePath, orderPath); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestValidateInvalidDate(t *testing.T) {
+	resumePath, orderPath := writeValidateFiles(t, `experience:
+  - id: exp-a
+    start_date: Agust 2020
+    end_date: Present
+`, "")
+
+	problems, err := Validate(resumePath, orderPath)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Line != 3 || problems[0].Column != 17 {
+		t.Fatalf("expected one problem at 3:17, got %v", problems)
+	}
+	if !strings.Contains(problems[0].Message, `invalid date "Agust 2020"`) {
+		t.Errorf("unexpected message: %s", problems[0].Message)
+	}
+}
@@ -0,0 +1,94 @@
+package resume
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Bullet variant names with special meaning
+const (
+	// VariantShort is the variant used when a resume overflows one page
+	VariantShort = "short"
+	// VariantAuto renders the default wording and falls back to
+	// VariantShort if the result is longer than one page
+	VariantAuto = "auto"
+)
+
+// TextFor returns the bullet's wording for a variant, falling back to Text
+// when the variant is empty or the bullet does not define it
+func (b Bullet) TextFor(variant string) string {
+	if text, ok := b.Variants[variant]; ok && text != "" {
+		return text
+	}
+	return b.Text
+}
+
+// Bullets returns every bullet in the resume: experience, project and
+// education coursework bullets
+func (r *Resume) Bullets() []Bullet {
+	var bullets []Bullet
+	for _, exp := range r.Experience {
+		for _, role := range exp.Roles() {
+			bullets = append(bullets, role.Bullets...)
+		}
+	}
+	for _, proj := range r.Projects {
+		bullets = append(bullets, proj.Bullets...)
+	}
+	for _, edu := range r.Education {
+		bullets = append(bullets, edu.Coursework...)
+	}
+	return bullets
+}
+
+// VariantNames returns the sorted names of all variants defined on any bullet
+func (r *Resume) VariantNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, bullet := range r.Bullets() {
+		for name := range bullet.Variants {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateVariant checks that a variant is empty, VariantAuto, or defined
+// on at least one bullet
+func (r *Resume) ValidateVariant(variant string) error {
+	if variant == "" || variant == VariantAuto {
+		return nil
+	}
+	names := r.VariantNames()
+	for _, name := range names {
+		if name == variant {
+			return nil
+		}
+	}
+	available := append([]string{VariantAuto}, names...)
+	return fmt.Errorf("unknown bullet variant %q (available: %s)", variant, strings.Join(available, ", "))
+}
+
+// SplitVariants separates per-bullet variant choices written as
+// "bullet-id:variant" from a list of selected IDs. It returns the bare IDs
+// and a map of bullet ID to chosen variant.
+func SplitVariants(ids []string) ([]string, map[string]string) {
+	var variants map[string]string
+	bare := make([]string, len(ids))
+	for i, id := range ids {
+		if base, variant, ok := strings.Cut(id, ":"); ok {
+			if variants == nil {
+				variants = make(map[string]string)
+			}
+			variants[base] = variant
+			id = base
+		}
+		bare[i] = id
+	}
+	return bare, variants
+}
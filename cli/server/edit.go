@@ -0,0 +1,591 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/evanqhuang/resume-cli/resume"
+	"github.com/go-chi/chi/v5"
+)
+
+// resumeETag returns the entity tag for a resume.yaml checksum
+func resumeETag(checksum string) string {
+	return `"` + checksum[:16] + `"`
+}
+
+// badRequest marks an edit error caused by invalid input
+type badRequest struct{ err error }
+
+func (e badRequest) Error() string { return e.err.Error() }
+
+func invalid(format string, args ...any) error {
+	return badRequest{fmt.Errorf(format, args...)}
+}
+
+// editResume applies edit to resume.yaml for a request. The request must
+// send the ETag of the version it was made against in If-Match: a missing
+// header gets 428 and a stale one 412. The JSON body is then decoded into
+// input unless it is nil. Edit errors get 404 for unknown IDs, 400 for
+// invalid input and 500 otherwise. Comments and formatting are kept, the
+// cache is refreshed, and the updated resume is returned with its new ETag.
+func (s *Server) editResume(w http.ResponseWriter, r *http.Request, status int, input any, edit func(d *resume.Document) error) {
+	s.editMu.Lock()
+	defer s.editMu.Unlock()
+
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		w.WriteHeader(http.StatusPreconditionRequired)
+		json.NewEncoder(w).Encode(map[string]string{"error": "If-Match header required; send the ETag from GET /api/resume"})
+		return
+	}
+
+	d, err := resume.OpenDocument(s.resumePath)
+	if err != nil {
+		log.Printf("Error opening resume: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	etag := resumeETag(d.Checksum())
+	if ifMatch != "*" && ifMatch != etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusPreconditionFailed)
+		json.NewEncoder(w).Encode(map[string]string{"error": "resume.yaml has changed since it was loaded; reload and try again"})
+		return
+	}
+	if input != nil {
+		if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid request body: " + err.Error()})
+			return
+		}
+	}
+
+	if err := edit(d); err != nil {
+		var code int
+		switch {
+		case errors.Is(err, resume.ErrUnknownID):
+			code = http.StatusNotFound
+		case errors.As(err, &badRequest{}), errors.Is(err, resume.ErrInvalidEdit):
+			code = http.StatusBadRequest
+		default:
+			log.Printf("Error editing resume: %v", err)
+			code = http.StatusInternalServerError
+		}
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	if err := d.Validate(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	if err := d.Save(); err != nil {
+		log.Printf("Error saving resume: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	s.respondWithResume(w, true, status)
+}
+
+// insertIndex converts an optional list position to InsertItem's index
+func insertIndex(index *int) int {
+	if index == nil {
+		return -1
+	}
+	return *index
+}
+
+// field is an optional string field keyed by its YAML name
+type field struct {
+	key   string
+	value *string
+}
+
+// setFields sets the non-nil fields of an item in order, so fields missing
+// from resume.yaml are always added in the same sequence
+func setFields(d *resume.Document, id string, fields []field) error {
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		if err := d.SetField(id, f.key, *f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDates sets the non-nil date fields of an item
+func setDates(d *resume.Document, id string, start, end *resume.Date) error {
+	if start != nil {
+		if strings.TrimSpace(start.Raw) == "" {
+			return invalid("start_date is required")
+		}
+		if err := checkDate("start_date", start); err != nil {
+			return err
+		}
+		if err := d.SetField(id, "start_date", start.Raw); err != nil {
+			return err
+		}
+	}
+	if end != nil {
+		if err := checkDate("end_date", end); err != nil {
+			return err
+		}
+		if err := d.SetField(id, "end_date", end.Raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDate rejects a date whose text is not a recognized date, which JSON
+// decoding keeps rather than failing on
+func checkDate(name string, date *resume.Date) error {
+	if _, err := resume.ParseDate(date.Raw); err != nil {
+		return invalid("%s: %v", name, err)
+	}
+	return nil
+}
+
+// required reports the first empty required field by name
+func required(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if strings.TrimSpace(fields[i+1]) == "" {
+			return invalid("%s is required", fields[i])
+		}
+	}
+	return nil
+}
+
+// value returns the string behind an optional field
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// itemKinds lists the IDs of each editable kind of item in res
+func itemKinds(res *resume.Resume) map[string]map[string]bool {
+	kinds := map[string]map[string]bool{
+		"experience entry": {},
+		"position":         {},
+		"project":          {},
+		"bullet":           {},
+		"leadership item":  {},
+		"skill":            {},
+	}
+	for _, exp := range res.Experience {
+		kinds["experience entry"][exp.ID] = true
+		for _, role := range exp.Roles() {
+			if role.ID != exp.ID {
+				kinds["position"][role.ID] = true
+			}
+			for _, bullet := range role.Bullets {
+				kinds["bullet"][bullet.ID] = true
+			}
+		}
+	}
+	for _, proj := range res.Projects {
+		kinds["project"][proj.ID] = true
+		for _, bullet := range proj.Bullets {
+			kinds["bullet"][bullet.ID] = true
+		}
+	}
+	for _, edu := range res.Education {
+		for _, course := range edu.Coursework {
+			kinds["bullet"][course.ID] = true
+		}
+	}
+	for _, lead := range res.Leadership {
+		kinds["leadership item"][lead.ID] = true
+	}
+	for _, skill := range res.Skills.All() {
+		kinds["skill"][skill.SkillID()] = true
+	}
+	return kinds
+}
+
+// requireKind checks that id names an item of the given kind
+func requireKind(d *resume.Document, kind, id string) error {
+	res, err := d.Resume()
+	if err != nil {
+		return err
+	}
+	if !itemKinds(res)[kind][id] {
+		return fmt.Errorf("no %s %q: %w", kind, id, resume.ErrUnknownID)
+	}
+	return nil
+}
+
+// ContactInput updates contact fields; omitted fields are unchanged
+type ContactInput struct {
+	Name     *string `json:"name"`
+	Location *string `json:"location"`
+	Email    *string `json:"email"`
+	Phone    *string `json:"phone"`
+	LinkedIn *string `json:"linkedin"`
+	GitHub   *string `json:"github"`
+}
+
+func (s *Server) handleUpdateContact(w http.ResponseWriter, r *http.Request) {
+	var input ContactInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		for _, f := range []field{
+			{"name", input.Name},
+			{"location", input.Location},
+			{"email", input.Email},
+			{"phone", input.Phone},
+			{"linkedin", input.LinkedIn},
+			{"github", input.GitHub},
+		} {
+			if f.value == nil {
+				continue
+			}
+			if err := d.SetValue(*f.value, "contact", f.key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SummaryInput replaces the summary
+type SummaryInput struct {
+	Summary string `json:"summary"`
+}
+
+func (s *Server) handleUpdateSummary(w http.ResponseWriter, r *http.Request) {
+	var input SummaryInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		return d.SetValue(input.Summary, "summary")
+	})
+}
+
+// ExperienceInput creates or updates an experience entry. On update the ID
+// comes from the URL and omitted fields are unchanged.
+type ExperienceInput struct {
+	ID        string       `json:"id"`
+	Title     *string      `json:"title"`
+	Company   *string      `json:"company"`
+	Location  *string      `json:"location"`
+	StartDate *resume.Date `json:"start_date"`
+	EndDate   *resume.Date `json:"end_date"`
+	Tags      []string     `json:"tags"`
+	Index     *int         `json:"index"`
+}
+
+func (s *Server) handleCreateExperience(w http.ResponseWriter, r *http.Request) {
+	var input ExperienceInput
+	s.editResume(w, r, http.StatusCreated, &input, func(d *resume.Document) error {
+		if err := required("id", input.ID, "title", value(input.Title), "company", value(input.Company)); err != nil {
+			return err
+		}
+		if input.StartDate == nil || strings.TrimSpace(input.StartDate.Raw) == "" {
+			return invalid("start_date is required")
+		}
+		if err := checkDate("start_date", input.StartDate); err != nil {
+			return err
+		}
+		entry := resume.ExperienceEntry{
+			ID:        input.ID,
+			Title:     value(input.Title),
+			Company:   value(input.Company),
+			Location:  value(input.Location),
+			StartDate: *input.StartDate,
+			Tags:      input.Tags,
+		}
+		if input.EndDate != nil {
+			if err := checkDate("end_date", input.EndDate); err != nil {
+				return err
+			}
+			entry.EndDate = *input.EndDate
+		}
+		return d.InsertItem("", resume.SectionExperience, insertIndex(input.Index), entry)
+	})
+}
+
+func (s *Server) handleUpdateExperience(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input ExperienceInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		if err := requireKind(d, "experience entry", id); err != nil {
+			return err
+		}
+		if err := setFields(d, id, []field{
+			{"title", input.Title},
+			{"company", input.Company},
+			{"location", input.Location},
+		}); err != nil {
+			return err
+		}
+		if err := setDates(d, id, input.StartDate, input.EndDate); err != nil {
+			return err
+		}
+		if input.Tags != nil {
+			return d.SetTags(id, input.Tags)
+		}
+		return nil
+	})
+}
+
+// ProjectInput creates or updates a project. On update the ID comes from
+// the URL and omitted fields are unchanged.
+type ProjectInput struct {
+	ID           string   `json:"id"`
+	Title        *string  `json:"title"`
+	Technologies *string  `json:"technologies"`
+	GitHub       *string  `json:"github"`
+	Tags         []string `json:"tags"`
+	Index        *int     `json:"index"`
+}
+
+func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	var input ProjectInput
+	s.editResume(w, r, http.StatusCreated, &input, func(d *resume.Document) error {
+		if err := required("id", input.ID, "title", value(input.Title)); err != nil {
+			return err
+		}
+		return d.InsertItem("", resume.SectionProjects, insertIndex(input.Index), resume.ProjectEntry{
+			ID:           input.ID,
+			Title:        value(input.Title),
+			Technologies: value(input.Technologies),
+			GitHub:       value(input.GitHub),
+			Tags:         input.Tags,
+		})
+	})
+}
+
+func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input ProjectInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		if err := requireKind(d, "project", id); err != nil {
+			return err
+		}
+		if err := setFields(d, id, []field{
+			{"title", input.Title},
+			{"technologies", input.Technologies},
+			{"github", input.GitHub},
+		}); err != nil {
+			return err
+		}
+		if input.Tags != nil {
+			return d.SetTags(id, input.Tags)
+		}
+		return nil
+	})
+}
+
+// BulletInput creates or updates a bullet. Parent is the experience entry,
+// position or project a new bullet is added to. On update the ID comes from
+// the URL and omitted fields are unchanged; an empty variants object
+// removes all variants.
+type BulletInput struct {
+	ID       string            `json:"id"`
+	Parent   string            `json:"parent"`
+	Text     *string           `json:"text"`
+	Tags     []string          `json:"tags"`
+	Variants map[string]string `json:"variants"`
+	Index    *int              `json:"index"`
+}
+
+func (s *Server) handleCreateBullet(w http.ResponseWriter, r *http.Request) {
+	var input BulletInput
+	s.editResume(w, r, http.StatusCreated, &input, func(d *resume.Document) error {
+		if err := required("id", input.ID, "parent", input.Parent, "text", value(input.Text)); err != nil {
+			return err
+		}
+		res, err := d.Resume()
+		if err != nil {
+			return err
+		}
+		kinds := itemKinds(res)
+		switch {
+		case kinds["position"][input.Parent], kinds["project"][input.Parent]:
+		case kinds["experience entry"][input.Parent]:
+			for _, exp := range res.Experience {
+				if exp.ID == input.Parent && len(exp.Positions) > 0 {
+					return invalid("%s has positions; add the bullet to one of them", input.Parent)
+				}
+			}
+		default:
+			return fmt.Errorf("no experience entry, position or project %q: %w", input.Parent, resume.ErrUnknownID)
+		}
+		if _, ok := input.Variants[resume.VariantAuto]; ok {
+			return invalid("variant name %q is reserved", resume.VariantAuto)
+		}
+		return d.InsertItem(input.Parent, "bullets", insertIndex(input.Index), resume.Bullet{
+			ID:       input.ID,
+			Text:     value(input.Text),
+			Tags:     input.Tags,
+			Variants: input.Variants,
+		})
+	})
+}
+
+func (s *Server) handleUpdateBullet(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input BulletInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		if err := requireKind(d, "bullet", id); err != nil {
+			return err
+		}
+		if input.Text != nil {
+			if strings.TrimSpace(*input.Text) == "" {
+				return invalid("text is required")
+			}
+			if err := d.SetText(id, *input.Text); err != nil {
+				return err
+			}
+		}
+		if input.Tags != nil {
+			if err := d.SetTags(id, input.Tags); err != nil {
+				return err
+			}
+		}
+		if input.Variants != nil {
+			if _, ok := input.Variants[resume.VariantAuto]; ok {
+				return invalid("variant name %q is reserved", resume.VariantAuto)
+			}
+			return d.SetVariants(id, input.Variants)
+		}
+		return nil
+	})
+}
+
+// LeadershipInput creates or updates a leadership item. On update the ID
+// comes from the URL and omitted fields are unchanged.
+type LeadershipInput struct {
+	ID    string   `json:"id"`
+	Text  *string  `json:"text"`
+	Tags  []string `json:"tags"`
+	Index *int     `json:"index"`
+}
+
+func (s *Server) handleCreateLeadership(w http.ResponseWriter, r *http.Request) {
+	var input LeadershipInput
+	s.editResume(w, r, http.StatusCreated, &input, func(d *resume.Document) error {
+		if err := required("id", input.ID, "text", value(input.Text)); err != nil {
+			return err
+		}
+		return d.InsertItem("", resume.SectionLeadership, insertIndex(input.Index), resume.LeadershipEntry{
+			ID:   input.ID,
+			Text: value(input.Text),
+			Tags: input.Tags,
+		})
+	})
+}
+
+func (s *Server) handleUpdateLeadership(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input LeadershipInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		if err := requireKind(d, "leadership item", id); err != nil {
+			return err
+		}
+		if input.Text != nil {
+			if strings.TrimSpace(*input.Text) == "" {
+				return invalid("text is required")
+			}
+			if err := d.SetText(id, *input.Text); err != nil {
+				return err
+			}
+		}
+		if input.Tags != nil {
+			return d.SetTags(id, input.Tags)
+		}
+		return nil
+	})
+}
+
+// SkillInput creates or updates a skill. Category names the category a new
+// skill is added to, which is created if missing. On update the ID comes
+// from the URL and omitted fields are unchanged.
+type SkillInput struct {
+	ID       string   `json:"id"`
+	Category string   `json:"category"`
+	Name     *string  `json:"name"`
+	Tags     []string `json:"tags"`
+}
+
+func (s *Server) handleCreateSkill(w http.ResponseWriter, r *http.Request) {
+	var input SkillInput
+	s.editResume(w, r, http.StatusCreated, &input, func(d *resume.Document) error {
+		if err := required("category", input.Category, "name", value(input.Name)); err != nil {
+			return err
+		}
+		return d.InsertSkill(input.Category, resume.SkillItem{
+			ID:   input.ID,
+			Name: value(input.Name),
+			Tags: input.Tags,
+		})
+	})
+}
+
+func (s *Server) handleUpdateSkill(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input SkillInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		if err := requireKind(d, "skill", id); err != nil {
+			return err
+		}
+		if input.Tags != nil {
+			if err := d.SetTags(id, input.Tags); err != nil {
+				return err
+			}
+		}
+		// Renaming changes a derived skill ID, so it is done last
+		if input.Name != nil {
+			if strings.TrimSpace(*input.Name) == "" {
+				return invalid("name is required")
+			}
+			return d.SetField(id, "name", *input.Name)
+		}
+		return nil
+	})
+}
+
+// handleDeleteItem removes an item of the given kind named by the URL
+func (s *Server) handleDeleteItem(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		s.editResume(w, r, http.StatusOK, nil, func(d *resume.Document) error {
+			if err := requireKind(d, kind, id); err != nil {
+				return err
+			}
+			return d.RemoveItem(id)
+		})
+	}
+}
+
+// TagsInput adds and removes tags on any item
+type TagsInput struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+func (s *Server) handleUpdateTags(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input TagsInput
+	s.editResume(w, r, http.StatusOK, &input, func(d *resume.Document) error {
+		for _, tag := range input.Add {
+			if strings.TrimSpace(tag) == "" || strings.ContainsAny(tag, ",\n") {
+				return invalid("invalid tag %q", tag)
+			}
+		}
+		if err := d.RemoveTags(id, input.Remove...); err != nil {
+			return err
+		}
+		return d.AddTags(id, input.Add...)
+	})
+}
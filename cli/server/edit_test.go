@@ -0,0 +1,153 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/generator"
+)
+
+const editFixture = `contact:
+  name: Test User
+
+experience:
+  - id: globex
+    company: Globex
+    positions:
+      - id: globex-lead
+        title: Lead
+        start_date: Jan 2018
+        end_date: Dec 2019
+        bullets:
+          - id: globex-1
+            text: Led things
+`
+
+// newEditServer serves a copy of editFixture and returns its path
+func newEditServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "resume.yaml")
+	if err := os.WriteFile(path, []byte(editFixture), 0644); err != nil {
+		t.Fatalf("failed to write resume: %v", err)
+	}
+	s := &Server{resumePath: path, templates: generator.NewRegistry(), events: newEventHub()}
+	s.setupRouter()
+	return s, path
+}
+
+// send makes a request against s, sending etag in If-Match when set
+func send(s *Server, method, target, etag, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateEntryThenBullet(t *testing.T) {
+	s, path := newEditServer(t)
+
+	w := send(s, http.MethodGet, "/api/resume", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/resume = %d: %s", w.Code, w.Body)
+	}
+	etag := w.Header().Get("ETag")
+
+	w = send(s, http.MethodPost, "/api/resume/experience", etag,
+		`{"id": "acme", "title": "Engineer", "company": "Acme", "start_date": "Jan 2020"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create experience = %d: %s", w.Code, w.Body)
+	}
+	etag = w.Header().Get("ETag")
+
+	w = send(s, http.MethodPost, "/api/resume/bullets", etag,
+		`{"id": "acme-1", "parent": "acme", "text": "Built things"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create bullet = %d: %s", w.Code, w.Body)
+	}
+	etag = w.Header().Get("ETag")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read resume: %v", err)
+	}
+	if !strings.Contains(string(data), "bullets:\n      - id: acme-1\n        text: Built things\n") {
+		t.Errorf("bullet not added under the new entry:\n%s", data)
+	}
+
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{`{"id": "globex-2", "parent": "globex", "text": "Ran things"}`, http.StatusBadRequest},
+		{`{"id": "acme-1", "parent": "acme", "text": "Built things again"}`, http.StatusBadRequest},
+		{`{"id": "x-1", "parent": "missing", "text": "Nowhere"}`, http.StatusNotFound},
+	} {
+		if w := send(s, http.MethodPost, "/api/resume/bullets", etag, tt.body); w.Code != tt.code {
+			t.Errorf("create bullet %s = %d, want %d: %s", tt.body, w.Code, tt.code, w.Body)
+		}
+	}
+}
+
+func TestUpdateContactFieldOrder(t *testing.T) {
+	s, path := newEditServer(t)
+	etag := send(s, http.MethodGet, "/api/resume", "", "").Header().Get("ETag")
+
+	w := send(s, http.MethodPut, "/api/resume/contact", etag,
+		`{"github": "github.com/test", "email": "test@example.com", "phone": "555-0100", "location": "Remote"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update contact = %d: %s", w.Code, w.Body)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read resume: %v", err)
+	}
+	want := "contact:\n  name: Test User\n  location: Remote\n  email: test@example.com\n  phone: 555-0100\n  github: github.com/test\n"
+	if !strings.HasPrefix(string(data), want) {
+		t.Errorf("contact fields out of order:\n%s", data)
+	}
+}
+
+func TestEditPreconditionBeforeBody(t *testing.T) {
+	s, _ := newEditServer(t)
+
+	for _, tt := range []struct {
+		etag string
+		code int
+	}{
+		{"", http.StatusPreconditionRequired},
+		{`"stale"`, http.StatusPreconditionFailed},
+	} {
+		if w := send(s, http.MethodPut, "/api/resume/summary", tt.etag, "not json"); w.Code != tt.code {
+			t.Errorf("If-Match %q = %d, want %d: %s", tt.etag, w.Code, tt.code, w.Body)
+		}
+	}
+}
+
+func TestExperienceDates(t *testing.T) {
+	s, _ := newEditServer(t)
+	etag := send(s, http.MethodGet, "/api/resume", "", "").Header().Get("ETag")
+
+	for _, body := range []string{
+		`{"id": "acme", "title": "Engineer", "company": "Acme"}`,
+		`{"id": "acme", "title": "Engineer", "company": "Acme", "start_date": ""}`,
+		`{"id": "acme", "title": "Engineer", "company": "Acme", "start_date": "soon"}`,
+		`{"id": "acme", "title": "Engineer", "company": "Acme", "start_date": "Jan 2020", "end_date": "later"}`,
+	} {
+		if w := send(s, http.MethodPost, "/api/resume/experience", etag, body); w.Code != http.StatusBadRequest {
+			t.Errorf("create experience %s = %d, want 400: %s", body, w.Code, w.Body)
+		}
+	}
+	for _, body := range []string{`{"start_date": ""}`, `{"end_date": "later"}`} {
+		if w := send(s, http.MethodPut, "/api/resume/experience/globex", etag, body); w.Code != http.StatusBadRequest {
+			t.Errorf("update experience %s = %d, want 400: %s", body, w.Code, w.Body)
+		}
+	}
+}
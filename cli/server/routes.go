@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +22,7 @@ type resumeCache struct {
 	mu       sync.RWMutex
 	resume   *resume.Resume
 	modTime  time.Time
+	etag     string
 	filePath string
 }
 
@@ -32,9 +36,36 @@ func registerRoutes(r chi.Router, s *Server) {
 		r.Get("/health", handleHealth)
 		r.Get("/resume", s.handleGetResume)
 		r.Post("/resume/reload", s.handleReloadResume)
+		r.Get("/events", s.handleEvents)
 		r.Post("/job/analyze", s.handleAnalyzeJob)
 		r.Post("/generate", s.handleGenerate)
+		r.Get("/render/html", s.handleRenderHTML)
+		r.Get("/templates", s.handleListTemplates)
+		r.Get("/filter", s.handleFilter)
 		r.Put("/order", s.handleSaveOrder)
+		r.Get("/profiles", s.handleListProfiles)
+		r.Get("/profiles/{name}", s.handleGetProfile)
+		r.Put("/profiles/{name}", s.handleSaveProfile)
+		r.Delete("/profiles/{name}", s.handleDeleteProfile)
+
+		r.Put("/resume/contact", s.handleUpdateContact)
+		r.Put("/resume/summary", s.handleUpdateSummary)
+		r.Post("/resume/experience", s.handleCreateExperience)
+		r.Put("/resume/experience/{id}", s.handleUpdateExperience)
+		r.Delete("/resume/experience/{id}", s.handleDeleteItem("experience entry"))
+		r.Post("/resume/projects", s.handleCreateProject)
+		r.Put("/resume/projects/{id}", s.handleUpdateProject)
+		r.Delete("/resume/projects/{id}", s.handleDeleteItem("project"))
+		r.Post("/resume/bullets", s.handleCreateBullet)
+		r.Put("/resume/bullets/{id}", s.handleUpdateBullet)
+		r.Delete("/resume/bullets/{id}", s.handleDeleteItem("bullet"))
+		r.Post("/resume/leadership", s.handleCreateLeadership)
+		r.Put("/resume/leadership/{id}", s.handleUpdateLeadership)
+		r.Delete("/resume/leadership/{id}", s.handleDeleteItem("leadership item"))
+		r.Post("/resume/skills", s.handleCreateSkill)
+		r.Put("/resume/skills/{id}", s.handleUpdateSkill)
+		r.Delete("/resume/skills/{id}", s.handleDeleteItem("skill"))
+		r.Patch("/resume/items/{id}/tags", s.handleUpdateTags)
 	})
 }
 
@@ -61,6 +92,10 @@ func loadResume(forceReload bool) (*resume.Resume, error) {
 
 	// Load from disk
 	log.Printf("Loading resume from %s", cache.filePath)
+	data, err := os.ReadFile(cache.filePath)
+	if err != nil {
+		return nil, err
+	}
 	r, err := resume.LoadResume(cache.filePath)
 	if err != nil {
 		return nil, err
@@ -69,19 +104,22 @@ func loadResume(forceReload bool) (*resume.Resume, error) {
 	// Update cache
 	cache.resume = r
 	cache.modTime = stat.ModTime()
+	cache.etag = resumeETag(resume.Checksum(data))
 
 	return r, nil
 }
 
 func (s *Server) handleGetResume(w http.ResponseWriter, r *http.Request) {
-	s.respondWithResume(w, false)
+	s.respondWithResume(w, false, http.StatusOK)
 }
 
 func (s *Server) handleReloadResume(w http.ResponseWriter, r *http.Request) {
-	s.respondWithResume(w, true)
+	s.respondWithResume(w, true, http.StatusOK)
 }
 
-func (s *Server) respondWithResume(w http.ResponseWriter, forceReload bool) {
+// respondWithResume writes the transformed resume with its ETag, which
+// editing requests send back in If-Match
+func (s *Server) respondWithResume(w http.ResponseWriter, forceReload bool, status int) {
 	res, err := loadResume(forceReload)
 	if err != nil {
 		log.Printf("Error loading resume: %v", err)
@@ -92,13 +130,17 @@ func (s *Server) respondWithResume(w http.ResponseWriter, forceReload bool) {
 
 	transformed := TransformResume(res)
 
-	order, err := LoadOrder(s.orderPath(), res)
+	order, err := resume.LoadOrder(s.orderPath(), res)
 	if err != nil {
 		log.Printf("Error loading order: %v", err)
 	} else {
 		ApplyOrder(transformed, order)
 	}
 
+	cache.mu.RLock()
+	w.Header().Set("ETag", cache.etag)
+	cache.mu.RUnlock()
+	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(transformed); err != nil {
 		log.Printf("Error encoding response: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -150,10 +192,26 @@ func (s *Server) handleAnalyzeJob(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GenerateRequest represents the request body for PDF generation
+// GenerateRequest represents the request body for resume generation
 type GenerateRequest struct {
+	// Format is "pdf" (the default), "md", "txt", "html" or "docx"
+	Format string `json:"format"`
+	// Selections lists selected IDs by kind. A bullet ID may be written as
+	// "id:variant" to choose that bullet's wording.
 	Selections map[string][]string `json:"selections"`
 	Template   string              `json:"template"`
+	// Sections overrides the section sequence from order.yaml for this request
+	Sections []string `json:"sections"`
+	// DateFormat is a Go time layout such as "Jan 2006"; empty keeps dates as written
+	DateFormat string `json:"date_format"`
+	// Variant selects the bullet wording, e.g. "short"; "auto" switches to
+	// short wording if the resume runs past one page
+	Variant string `json:"variant"`
+	// Engine is the PDF engine, e.g. "lualatex" or "native"; empty uses
+	// the template's engine, or the native renderer if it is not installed.
+	// Otherwise $RESUME_PDF_ENGINE is used, then the first TeX engine
+	// installed, then the native renderer. Other formats ignore it.
+	Engine string `json:"engine"`
 }
 
 func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
@@ -165,6 +223,35 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Format == "" {
+		req.Format = generator.FormatPDF
+	}
+	if err := generator.ValidateFormat(req.Format); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := s.templates.Get(req.Template)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	var engine string
+	if req.Format == generator.FormatPDF {
+		engine, err = generator.ResolveEngine(req.Engine, tmpl)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if engine == generator.EngineNative && req.Engine == "" && tmpl.Engine != "" {
+			log.Printf("Template %s requires %s, which is not installed; using the native PDF renderer", tmpl.Name, tmpl.Engine)
+		}
+	}
+
 	res, err := loadResume(false)
 	if err != nil {
 		log.Printf("Error loading resume: %v", err)
@@ -173,32 +260,295 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Convert selections to map[string]bool
+	if err := res.ValidateSections(req.Sections); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	order, err := resume.LoadOrder(s.orderPath(), res)
+	if err != nil {
+		log.Printf("Error loading order: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	// Convert selections to map[string]bool, collecting per-bullet variants
 	selectedIDs := make(map[string]bool)
-	for _, ids := range req.Selections {
+	bulletVariants := make(map[string]string)
+	for key, ids := range req.Selections {
+		if key == skillSelectionKey {
+			continue
+		}
+		ids, variants := resume.SplitVariants(ids)
 		for _, id := range ids {
 			selectedIDs[id] = true
 		}
+		for id, variant := range variants {
+			bulletVariants[id] = variant
+		}
+	}
+
+	if err := res.ValidateVariant(req.Variant); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	for id, variant := range bulletVariants {
+		if err := res.ValidateVariant(variant); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": id + ": " + err.Error()})
+			return
+		}
+	}
+
+	// Sections whose items were all deselected are dropped entirely
+	var dropped []string
+	if skillSelection, ok := req.Selections[skillSelectionKey]; ok {
+		skillIDs := res.Skills.ResolveIDs(skillSelection)
+		if len(skillIDs) == 0 {
+			dropped = append(dropped, resume.SectionSkills)
+		}
+		for _, id := range skillIDs {
+			selectedIDs[id] = true
+		}
+	}
+	if _, ok := req.Selections[customItemSelectionKey]; ok {
+		for _, section := range res.Sections {
+			if section.SelectedItemIDs(selectedIDs) == nil {
+				dropped = append(dropped, section.ID)
+			}
+		}
+	}
+	sections := req.Sections
+	if len(dropped) > 0 {
+		sections = withoutSections(sections, order, res, dropped)
+	}
+
+	content, err := generator.Render(req.Format, res, selectedIDs, generator.Options{
+		Template:       req.Template,
+		Templates:      s.templates,
+		Order:          order,
+		Sections:       sections,
+		DateFormat:     req.DateFormat,
+		Variant:        req.Variant,
+		BulletVariants: bulletVariants,
+		Engine:         engine,
+	})
+	if err != nil {
+		log.Printf("Error generating %s: %v", req.Format, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", generator.ContentType(req.Format))
+	w.Header().Set("Content-Disposition", "attachment; filename=resume."+req.Format)
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// handleRenderHTML renders the resume as a standalone HTML page for
+// in-browser previews. Query parameters mirror generate: ids (comma-separated,
+// "id:variant" allowed), query or profile select content, and template,
+// sections, date_format and variant style it. Without ids, query or profile
+// every item is shown.
+func (s *Server) handleRenderHTML(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	res, profiles, ok := s.loadProfiles(w)
+	if !ok {
+		return
+	}
+
+	var profile *resume.Profile
+	if name := params.Get("profile"); name != "" {
+		var err error
+		if profile, err = profiles.Get(name); err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	order, err := resume.LoadOrder(s.orderPath(), res)
+	if err != nil {
+		log.Printf("Error loading order: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	opts := generator.Options{
+		Template:   params.Get("template"),
+		Templates:  s.templates,
+		Order:      order,
+		Sections:   listParam(params, "sections"),
+		DateFormat: params.Get("date_format"),
+		Variant:    params.Get("variant"),
+	}
+	if profile != nil {
+		opts.Order = profile.ApplyOrder(order)
+		if opts.Template == "" {
+			opts.Template = profile.Template
+		}
+		if opts.Variant == "" {
+			opts.Variant = profile.Variant
+		}
+	}
+
+	var selectedIDs map[string]bool
+	ids, bulletVariants := resume.SplitVariants(listParam(params, "ids"))
+	switch {
+	case len(ids) > 0:
+		err = res.Index().Check(ids)
+		selectedIDs = res.FilterByIDs(ids)
+	case params.Get("query") != "":
+		var query *resume.Query
+		query, err = resume.ParseQuery(params.Get("query"))
+		if err == nil {
+			err = res.ValidateQuery(query)
+		}
+		if err == nil {
+			selectedIDs = res.FilterByQuery(query)
+		}
+	case profile != nil:
+		selectedIDs, bulletVariants = profile.Selection(res)
+	}
+	if err == nil {
+		err = res.ValidateSections(opts.Sections)
+	}
+	if err == nil {
+		err = res.ValidateVariant(opts.Variant)
+	}
+	for _, variant := range bulletVariants {
+		if err == nil {
+			err = res.ValidateVariant(variant)
+		}
+	}
+	if err == nil {
+		_, err = s.templates.Get(opts.Template)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	opts.BulletVariants = bulletVariants
+
+	content, err := generator.Render(generator.FormatHTML, res, selectedIDs, opts)
+	if err != nil {
+		log.Printf("Error rendering HTML: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", generator.ContentType(generator.FormatHTML))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// listParam returns the comma-separated values of a query parameter,
+// which may also be repeated
+func listParam(params url.Values, key string) []string {
+	var list []string
+	for _, value := range params[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// skillSelectionKey is the selections entry that carries skill choices
+const skillSelectionKey = "skill_ids"
+
+// customItemSelectionKey is the selections entry that carries custom section item choices
+const customItemSelectionKey = "custom_item_ids"
+
+// withoutSections returns the effective section sequence minus the named sections
+func withoutSections(sections []string, order *resume.SectionOrder, res *resume.Resume, names []string) []string {
+	if len(sections) == 0 {
+		sections = order.SectionSequence(res)
+	}
+	drop := make(map[string]bool, len(names))
+	for _, name := range names {
+		drop[name] = true
+	}
+	var result []string
+	for _, section := range sections {
+		if !drop[section] {
+			result = append(result, section)
+		}
 	}
+	return result
+}
+
+// TemplateInfo describes a LaTeX template available for generation
+type TemplateInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+}
+
+func (s *Server) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	var list []TemplateInfo
+	for _, t := range s.templates.List() {
+		list = append(list, TemplateInfo{
+			Name:        t.Name,
+			Description: t.Description,
+			Default:     t.Name == generator.DefaultTemplate,
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Error encoding response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
 
-	// Generate PDF
-	pdfBytes, err := generator.GeneratePDF(res, selectedIDs)
+// FilterResponse lists the IDs of items matching a tag query
+type FilterResponse struct {
+	Query string   `json:"query"`
+	IDs   []string `json:"ids"`
+}
+
+func (s *Server) handleFilter(w http.ResponseWriter, r *http.Request) {
+	res, err := loadResume(false)
 	if err != nil {
-		log.Printf("Error generating PDF: %v", err)
+		log.Printf("Error loading resume: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
 
-	// Return PDF
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", "attachment; filename=resume.pdf")
+	text := r.URL.Query().Get("query")
+	query, err := resume.ParseQuery(text)
+	if err == nil {
+		err = res.ValidateQuery(query)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	ids := make([]string, 0)
+	for id := range res.FilterByQuery(query) {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
 	w.WriteHeader(http.StatusOK)
-	w.Write(pdfBytes)
+	json.NewEncoder(w).Encode(FilterResponse{Query: text, IDs: ids})
 }
 
 func (s *Server) handleSaveOrder(w http.ResponseWriter, r *http.Request) {
-	var partial PartialSectionOrder
+	var partial resume.PartialSectionOrder
 	if err := json.NewDecoder(r.Body).Decode(&partial); err != nil {
 		log.Printf("Error decoding request: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
@@ -214,7 +564,7 @@ func (s *Server) handleSaveOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	existing, err := LoadOrder(s.orderPath(), res)
+	existing, err := resume.LoadOrder(s.orderPath(), res)
 	if err != nil {
 		log.Printf("Error loading existing order: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -222,9 +572,17 @@ func (s *Server) handleSaveOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	MergeOrder(existing, &partial)
+	if partial.Sections != nil {
+		if err := res.ValidateSections(*partial.Sections); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	resume.MergeOrder(existing, &partial)
 
-	if err := SaveOrder(s.orderPath(), existing); err != nil {
+	if err := resume.SaveOrder(s.orderPath(), existing); err != nil {
 		log.Printf("Error saving order: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -234,3 +592,155 @@ func (s *Server) handleSaveOrder(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(existing)
 }
+
+// ProfileResponse is a saved profile with its name. SelectedIDs and
+// BulletVariants resolve the profile against the current resume so clients
+// can apply it without evaluating tags themselves.
+type ProfileResponse struct {
+	Name string `json:"name"`
+	resume.Profile
+	SelectedIDs    []string          `json:"selected_ids"`
+	BulletVariants map[string]string `json:"bullet_variants,omitempty"`
+}
+
+func newProfileResponse(name string, profile *resume.Profile, res *resume.Resume) ProfileResponse {
+	selected, variants := profile.Selection(res)
+	ids := make([]string, 0, len(selected))
+	for id := range selected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ProfileResponse{
+		Name:           name,
+		Profile:        *profile,
+		SelectedIDs:    ids,
+		BulletVariants: variants,
+	}
+}
+
+func (s *Server) handleListProfiles(w http.ResponseWriter, r *http.Request) {
+	res, profiles, ok := s.loadProfiles(w)
+	if !ok {
+		return
+	}
+
+	result := make([]ProfileResponse, 0, len(profiles))
+	for _, name := range profiles.Names() {
+		result = append(result, newProfileResponse(name, profiles[name], res))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) handleGetProfile(w http.ResponseWriter, r *http.Request) {
+	res, profiles, ok := s.loadProfiles(w)
+	if !ok {
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	profile, err := profiles.Get(name)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProfileResponse(name, profile, res))
+}
+
+func (s *Server) handleSaveProfile(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := resume.ValidateProfileName(name); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	var profile resume.Profile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	res, profiles, ok := s.loadProfiles(w)
+	if !ok {
+		return
+	}
+
+	if err := profile.Validate(res); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	if profile.Template != "" {
+		if _, err := s.templates.Get(profile.Template); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	profiles[name] = &profile
+	if err := resume.SaveProfiles(s.profilesPath(), profiles); err != nil {
+		log.Printf("Error saving profiles: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProfileResponse(name, &profile, res))
+}
+
+func (s *Server) handleDeleteProfile(w http.ResponseWriter, r *http.Request) {
+	profiles, err := resume.LoadProfiles(s.profilesPath())
+	if err != nil {
+		log.Printf("Error loading profiles: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if _, err := profiles.Get(name); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	delete(profiles, name)
+
+	if err := resume.SaveProfiles(s.profilesPath(), profiles); err != nil {
+		log.Printf("Error saving profiles: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadProfiles loads the resume and profiles.yaml, writing an error
+// response and returning false if either fails
+func (s *Server) loadProfiles(w http.ResponseWriter) (*resume.Resume, resume.Profiles, bool) {
+	res, err := loadResume(false)
+	if err != nil {
+		log.Printf("Error loading resume: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return nil, nil, false
+	}
+
+	profiles, err := resume.LoadProfiles(s.profilesPath())
+	if err != nil {
+		log.Printf("Error loading profiles: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return nil, nil, false
+	}
+	return res, profiles, true
+}
@@ -8,9 +8,12 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/evanqhuang/resume-cli/generator"
+	"github.com/evanqhuang/resume-cli/resume"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -19,19 +22,45 @@ import (
 type Server struct {
 	router     *chi.Mux
 	resumePath string
+	templates  *generator.Registry
+	events     *eventHub
+	// editMu serializes edits to resume.yaml
+	editMu sync.Mutex
 }
 
 func (s *Server) orderPath() string {
-	return filepath.Join(filepath.Dir(s.resumePath), "order.yaml")
+	return resume.OrderPath(s.resumePath)
+}
+
+func (s *Server) profilesPath() string {
+	return resume.ProfilesPath(s.resumePath)
+}
+
+// templateDir is where user LaTeX templates are loaded from
+func (s *Server) templateDir() string {
+	return filepath.Join(filepath.Dir(s.resumePath), "templates")
 }
 
 func Start(resumePath string, port int) error {
 	s := &Server{
 		resumePath: resumePath,
+		templates:  generator.NewRegistry(),
+		events:     newEventHub(),
+	}
+
+	if err := s.templates.LoadDir(s.templateDir()); err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
 	}
 
 	s.setupRouter()
 
+	watcher, err := s.watchFiles()
+	if err != nil {
+		log.Printf("Warning: live reload disabled, cannot watch %s: %v", s.resumePath, err)
+	} else {
+		defer watcher.Close()
+	}
+
 	addr := fmt.Sprintf(":%d", port)
 	srv := &http.Server{
 		Addr:         addr,
@@ -41,6 +70,9 @@ func Start(resumePath string, port int) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Event streams never finish on their own, so end them on shutdown
+	srv.RegisterOnShutdown(s.events.close)
+
 	log.Printf("Starting server on http://localhost%s", addr)
 
 	// Channel to listen for errors from the server
@@ -89,8 +121,9 @@ func (s *Server) setupRouter() {
 	// CORS middleware for development
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -2,34 +2,58 @@ package server
 
 import (
 	"sort"
+	"time"
 
 	"github.com/evanqhuang/resume-cli/resume"
 )
 
 // TransformedResume is the response format expected by the frontend
 type TransformedResume struct {
+	Sections   []string                 `json:"sections"`
 	Contact    resume.ContactInfo       `json:"contact"`
 	Summary    string                   `json:"summary"`
-	Education  resume.EducationEntry    `json:"education"`
+	Education  []TransformedEducation   `json:"education"`
 	Skills     []SkillCategory          `json:"skills"`
 	Experience []TransformedExperience  `json:"experience"`
 	Projects   []TransformedProject     `json:"projects"`
 	Leadership []TransformedLeadership  `json:"leadership"`
+	// CustomSections holds user-defined sections in YAML order
+	CustomSections []TransformedCustomSection `json:"custom_sections"`
 }
 
 // SkillCategory represents a skill category with selected items
 type SkillCategory struct {
 	Category string              `json:"category"`
+	Label    string              `json:"label"`
 	Items    []TransformedSkill  `json:"items"`
 }
 
 // TransformedSkill is a skill item with a selected flag
 type TransformedSkill struct {
+	ID       string   `json:"id"`
 	Name     string   `json:"name"`
 	Tags     []string `json:"tags"`
 	Selected bool     `json:"selected"`
 }
 
+// TransformedEducation is an education entry with selected flags
+type TransformedEducation struct {
+	ID          string              `json:"id"`
+	Institution string              `json:"institution"`
+	Location    string              `json:"location"`
+	Degree      string              `json:"degree"`
+	Focus       string              `json:"focus,omitempty"`
+	Program     string              `json:"program,omitempty"`
+	Minor       string              `json:"minor"`
+	GPA         string              `json:"gpa"`
+	Honors      string              `json:"honors"`
+	StartDate   string              `json:"start_date,omitempty"`
+	EndDate     string              `json:"end_date,omitempty"`
+	Tags        []string            `json:"tags"`
+	Coursework  []TransformedBullet `json:"coursework"`
+	Selected    bool                `json:"selected"`
+}
+
 // TransformedExperience is an experience entry with selected flags
 type TransformedExperience struct {
 	ID        string              `json:"id"`
@@ -38,9 +62,24 @@ type TransformedExperience struct {
 	Location  string              `json:"location"`
 	StartDate string              `json:"start_date"`
 	EndDate   string              `json:"end_date"`
+	Duration  string              `json:"duration,omitempty"`
 	Tags      []string            `json:"tags"`
 	Bullets   []TransformedBullet `json:"bullets"`
 	Selected  bool                `json:"selected"`
+	// Positions lists the roles held at the company; Bullets holds the
+	// bullets of every position in position order
+	Positions []TransformedPosition `json:"positions"`
+}
+
+// TransformedPosition is one role within an experience entry
+type TransformedPosition struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Location  string   `json:"location,omitempty"`
+	StartDate string   `json:"start_date"`
+	EndDate   string   `json:"end_date"`
+	Duration  string   `json:"duration,omitempty"`
+	BulletIDs []string `json:"bullet_ids"`
 }
 
 // TransformedProject is a project entry with selected flags
@@ -56,8 +95,30 @@ type TransformedProject struct {
 
 // TransformedBullet is a bullet with a selected flag
 type TransformedBullet struct {
+	ID       string            `json:"id"`
+	Text     string            `json:"text"`
+	Tags     []string          `json:"tags"`
+	Variants map[string]string `json:"variants,omitempty"`
+	Selected bool              `json:"selected"`
+}
+
+// TransformedCustomSection is a user-defined section with selectable items
+type TransformedCustomSection struct {
+	ID     string                  `json:"id"`
+	Title  string                  `json:"title"`
+	Layout string                  `json:"layout"`
+	Items  []TransformedCustomItem `json:"items"`
+}
+
+// TransformedCustomItem is a custom section item with a selected flag
+type TransformedCustomItem struct {
 	ID       string   `json:"id"`
-	Text     string   `json:"text"`
+	Text     string   `json:"text,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Subtitle string   `json:"subtitle,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	Key      string   `json:"key,omitempty"`
+	Value    string   `json:"value,omitempty"`
 	Tags     []string `json:"tags"`
 	Selected bool     `json:"selected"`
 }
@@ -73,41 +134,40 @@ type TransformedLeadership struct {
 // TransformResume converts a Resume to the frontend-expected format
 func TransformResume(r *resume.Resume) *TransformedResume {
 	return &TransformedResume{
+		Sections:   r.SectionNames(),
 		Contact:    r.Contact,
 		Summary:    r.Summary,
-		Education:  r.Education,
-		Skills:     transformSkills(r.Skills),
-		Experience: transformExperience(r.Experience),
-		Projects:   transformProjects(r.Projects),
-		Leadership: transformLeadership(r.Leadership),
+		Education:  transformEducation(r.Education, r.Taxonomy),
+		Skills:     transformSkills(r.Skills, r.Taxonomy),
+		Experience: transformExperience(r.Experience, r.Taxonomy),
+		Projects:   transformProjects(r.Projects, r.Taxonomy),
+		Leadership: transformLeadership(r.Leadership, r.Taxonomy),
+
+		CustomSections: transformCustomSections(r.Sections, r.Taxonomy),
 	}
 }
 
-func transformSkills(skills resume.Skills) []SkillCategory {
-	return []SkillCategory{
-		{
-			Category: "languages",
-			Items:    transformSkillItems(skills.Languages),
-		},
-		{
-			Category: "frameworks",
-			Items:    transformSkillItems(skills.Frameworks),
-		},
-		{
-			Category: "cloud",
-			Items:    transformSkillItems(skills.Cloud),
-		},
+func transformSkills(skills resume.Skills, tax *resume.Taxonomy) []SkillCategory {
+	result := make([]SkillCategory, len(skills))
+	for i, category := range skills {
+		result[i] = SkillCategory{
+			Category: category.Name,
+			Label:    category.DisplayLabel(),
+			Items:    transformSkillItems(category.Items, tax),
+		}
 	}
+	return result
 }
 
-func transformSkillItems(items []resume.SkillItem) []TransformedSkill {
+func transformSkillItems(items []resume.SkillItem, tax *resume.Taxonomy) []TransformedSkill {
 	result := make([]TransformedSkill, len(items))
 	for i, item := range items {
-		tags := item.Tags
+		tags := tax.Expand(item.Tags)
 		if tags == nil {
 			tags = []string{}
 		}
 		result[i] = TransformedSkill{
+			ID:       item.SkillID(),
 			Name:     item.Name,
 			Tags:     tags,
 			Selected: true,
@@ -116,32 +176,78 @@ func transformSkillItems(items []resume.SkillItem) []TransformedSkill {
 	return result
 }
 
-func transformExperience(entries []resume.ExperienceEntry) []TransformedExperience {
+func transformEducation(entries resume.Education, tax *resume.Taxonomy) []TransformedEducation {
+	result := make([]TransformedEducation, len(entries))
+	for i, entry := range entries {
+		tags := tax.Expand(entry.Tags)
+		if tags == nil {
+			tags = []string{}
+		}
+		result[i] = TransformedEducation{
+			ID:          entry.EntryID(),
+			Institution: entry.Institution,
+			Location:    entry.Location,
+			Degree:      entry.Degree,
+			Focus:       entry.Focus,
+			Program:     entry.Program,
+			Minor:       entry.Minor,
+			GPA:         entry.GPA,
+			Honors:      entry.Honors,
+			StartDate:   entry.StartDate.String(),
+			EndDate:     entry.EndDate.String(),
+			Tags:        tags,
+			Coursework:  transformBullets(entry.Coursework, tax),
+			Selected:    true,
+		}
+	}
+	return result
+}
+
+func transformExperience(entries []resume.ExperienceEntry, tax *resume.Taxonomy) []TransformedExperience {
 	result := make([]TransformedExperience, len(entries))
 	for i, entry := range entries {
-		tags := entry.Tags
+		tags := tax.Expand(entry.Tags)
 		if tags == nil {
 			tags = []string{}
 		}
+		now := time.Now()
+		roles := entry.Roles()
+		bullets := []TransformedBullet{}
+		positions := make([]TransformedPosition, len(roles))
+		for j, role := range roles {
+			bullets = append(bullets, transformBullets(role.Bullets, tax)...)
+			positions[j] = TransformedPosition{
+				ID:        role.ID,
+				Title:     role.Title,
+				Location:  role.Location,
+				StartDate: role.StartDate.String(),
+				EndDate:   role.EndDate.String(),
+				Duration:  resume.FormatMonths(resume.MonthsBetween(role.StartDate, role.EndDate, now)),
+				BulletIDs: resume.BulletIDs(role.Bullets),
+			}
+		}
+		start, end := entry.Span(now)
 		result[i] = TransformedExperience{
 			ID:        entry.ID,
-			Title:     entry.Title,
+			Title:     roles[0].Title,
 			Company:   entry.Company,
 			Location:  entry.Location,
-			StartDate: entry.StartDate,
-			EndDate:   entry.EndDate,
+			StartDate: start.String(),
+			EndDate:   end.String(),
+			Duration:  resume.FormatMonths(resume.MonthsBetween(start, end, now)),
 			Tags:      tags,
-			Bullets:   transformBullets(entry.Bullets),
+			Bullets:   bullets,
 			Selected:  true,
+			Positions: positions,
 		}
 	}
 	return result
 }
 
-func transformProjects(entries []resume.ProjectEntry) []TransformedProject {
+func transformProjects(entries []resume.ProjectEntry, tax *resume.Taxonomy) []TransformedProject {
 	result := make([]TransformedProject, len(entries))
 	for i, entry := range entries {
-		tags := entry.Tags
+		tags := tax.Expand(entry.Tags)
 		if tags == nil {
 			tags = []string{}
 		}
@@ -151,17 +257,17 @@ func transformProjects(entries []resume.ProjectEntry) []TransformedProject {
 			Technologies: entry.Technologies,
 			GitHub:       entry.GitHub,
 			Tags:         tags,
-			Bullets:      transformBullets(entry.Bullets),
+			Bullets:      transformBullets(entry.Bullets, tax),
 			Selected:     true,
 		}
 	}
 	return result
 }
 
-func transformBullets(bullets []resume.Bullet) []TransformedBullet {
+func transformBullets(bullets []resume.Bullet, tax *resume.Taxonomy) []TransformedBullet {
 	result := make([]TransformedBullet, len(bullets))
 	for i, bullet := range bullets {
-		tags := bullet.Tags
+		tags := tax.Expand(bullet.Tags)
 		if tags == nil {
 			tags = []string{}
 		}
@@ -169,16 +275,17 @@ func transformBullets(bullets []resume.Bullet) []TransformedBullet {
 			ID:       bullet.ID,
 			Text:     bullet.Text,
 			Tags:     tags,
+			Variants: bullet.Variants,
 			Selected: true,
 		}
 	}
 	return result
 }
 
-func transformLeadership(entries []resume.LeadershipEntry) []TransformedLeadership {
+func transformLeadership(entries []resume.LeadershipEntry, tax *resume.Taxonomy) []TransformedLeadership {
 	result := make([]TransformedLeadership, len(entries))
 	for i, entry := range entries {
-		tags := entry.Tags
+		tags := tax.Expand(entry.Tags)
 		if tags == nil {
 			tags = []string{}
 		}
@@ -192,43 +299,81 @@ func transformLeadership(entries []resume.LeadershipEntry) []TransformedLeadersh
 	return result
 }
 
-// toOrderMap builds a map from ID to position for O(1) lookup.
-func toOrderMap(ids []string) map[string]int {
-	m := make(map[string]int, len(ids))
-	for i, id := range ids {
-		m[id] = i
-	}
-	return m
-}
-
-// sortByOrder sorts a slice in-place according to an ordered list of IDs.
-// Items not in the order list are placed at the end, preserving relative order.
-func sortByOrder[T any](items []T, orderIDs []string, getID func(T) string) {
-	if len(orderIDs) == 0 {
-		return
-	}
-	om := toOrderMap(orderIDs)
-	fallback := len(items)
-	sort.SliceStable(items, func(i, j int) bool {
-		iOrder, iOk := om[getID(items[i])]
-		jOrder, jOk := om[getID(items[j])]
-		if !iOk {
-			iOrder = fallback
+func transformCustomSections(sections []resume.CustomSection, tax *resume.Taxonomy) []TransformedCustomSection {
+	result := make([]TransformedCustomSection, len(sections))
+	for i, section := range sections {
+		items := make([]TransformedCustomItem, len(section.Items))
+		for j, item := range section.Items {
+			tags := tax.Expand(item.Tags)
+			if tags == nil {
+				tags = []string{}
+			}
+			items[j] = TransformedCustomItem{
+				ID:       item.ID,
+				Text:     item.Text,
+				Title:    item.Title,
+				Subtitle: item.Subtitle,
+				Date:     item.Date,
+				Key:      item.Key,
+				Value:    item.Value,
+				Tags:     tags,
+				Selected: true,
+			}
 		}
-		if !jOk {
-			jOrder = fallback
+		result[i] = TransformedCustomSection{
+			ID:     section.ID,
+			Title:  section.Title,
+			Layout: section.LayoutName(),
+			Items:  items,
 		}
-		return iOrder < jOrder
-	})
+	}
+	return result
 }
 
 // ApplyOrder sorts transformed resume arrays according to custom order.
 // Operates on TransformedResume to avoid mutating the cached Resume.
-func ApplyOrder(tr *TransformedResume, order *SectionOrder) {
+func ApplyOrder(tr *TransformedResume, order *resume.SectionOrder) {
 	if order == nil {
 		return
 	}
-	sortByOrder(tr.Experience, order.Experience, func(e TransformedExperience) string { return e.ID })
-	sortByOrder(tr.Projects, order.Projects, func(p TransformedProject) string { return p.ID })
-	sortByOrder(tr.Leadership, order.Leadership, func(l TransformedLeadership) string { return l.ID })
+	if len(order.Sections) > 0 {
+		tr.Sections = order.Sections
+	}
+	resume.SortByOrder(tr.Education, order.Education, func(e TransformedEducation) string { return e.ID })
+	resume.SortByOrder(tr.Experience, order.Experience, func(e TransformedExperience) string { return e.ID })
+	resume.SortByOrder(tr.Projects, order.Projects, func(p TransformedProject) string { return p.ID })
+	resume.SortByOrder(tr.Leadership, order.Leadership, func(l TransformedLeadership) string { return l.ID })
+
+	getBulletID := func(b TransformedBullet) string { return b.ID }
+	for _, edu := range tr.Education {
+		resume.SortByOrder(edu.Coursework, order.BulletOrder(edu.ID), getBulletID)
+	}
+	for _, exp := range tr.Experience {
+		// An entry without explicit positions orders its bullets under its
+		// own ID, as its single implicit position shares that ID
+		if len(exp.Positions) == 0 || (len(exp.Positions) == 1 && exp.Positions[0].ID == exp.ID) {
+			resume.SortByOrder(exp.Bullets, order.BulletOrder(exp.ID), getBulletID)
+			for _, pos := range exp.Positions {
+				resume.SortByOrder(pos.BulletIDs, order.BulletOrder(exp.ID), func(id string) string { return id })
+			}
+			continue
+		}
+		// Positions are ordered under the entry ID and bullets under each
+		// position ID; the flattened bullets follow the resulting order
+		resume.SortByOrder(exp.Positions, order.BulletOrder(exp.ID), func(p TransformedPosition) string { return p.ID })
+		rank := make(map[string]int, len(exp.Bullets))
+		for _, pos := range exp.Positions {
+			resume.SortByOrder(pos.BulletIDs, order.BulletOrder(pos.ID), func(id string) string { return id })
+			for _, id := range pos.BulletIDs {
+				rank[id] = len(rank)
+			}
+		}
+		sort.SliceStable(exp.Bullets, func(a, b int) bool { return rank[exp.Bullets[a].ID] < rank[exp.Bullets[b].ID] })
+	}
+	for _, proj := range tr.Projects {
+		resume.SortByOrder(proj.Bullets, order.BulletOrder(proj.ID), getBulletID)
+	}
+	for _, section := range tr.CustomSections {
+		resume.SortByOrder(section.Items, order.BulletOrder(section.ID), func(i TransformedCustomItem) string { return i.ID })
+	}
 }
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/evanqhuang/resume-cli/resume"
+)
+
+func TestApplyOrderSingleExplicitPosition(t *testing.T) {
+	res := &resume.Resume{
+		Experience: []resume.ExperienceEntry{{
+			ID:      "acme",
+			Company: "Acme",
+			Positions: []resume.Position{{
+				ID:    "acme-eng",
+				Title: "Engineer",
+				Bullets: []resume.Bullet{
+					{ID: "acme-1", Text: "Built things"},
+					{ID: "acme-2", Text: "Ran things"},
+				},
+			}},
+		}},
+	}
+	order := &resume.SectionOrder{Bullets: map[string][]string{"acme-eng": {"acme-2", "acme-1"}}}
+
+	tr := TransformResume(res)
+	ApplyOrder(tr, order)
+
+	exp := tr.Experience[0]
+	var got []string
+	for _, b := range exp.Bullets {
+		got = append(got, b.ID)
+	}
+	want := []string{"acme-2", "acme-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bullets = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(exp.Positions[0].BulletIDs, want) {
+		t.Errorf("Positions[0].BulletIDs = %v, want %v", exp.Positions[0].BulletIDs, want)
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evanqhuang/resume-cli/resume"
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadEvent tells clients that watched files changed and whether they
+// still parse. Clients refetch the resume when OK is true.
+type ReloadEvent struct {
+	// Files are the base names of the files that changed; empty for the
+	// status sent when a client connects
+	Files []string `json:"files,omitempty"`
+	OK    bool     `json:"ok"`
+	// File, Line and Error describe the first parse error
+	File  string `json:"file,omitempty"`
+	Line  int    `json:"line,omitempty"`
+	Error string `json:"error,omitempty"`
+	ETag  string `json:"etag,omitempty"`
+}
+
+// eventHub fans reload events out to connected clients
+type eventHub struct {
+	mu      sync.Mutex
+	clients map[chan ReloadEvent]struct{}
+	done    chan struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		clients: make(map[chan ReloadEvent]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (h *eventHub) subscribe() chan ReloadEvent {
+	ch := make(chan ReloadEvent, 4)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan ReloadEvent) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	h.mu.Unlock()
+}
+
+// publish sends ev to every client, skipping clients that are not keeping up
+func (h *eventHub) publish(ev ReloadEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// close ends all event streams so the server can shut down
+func (h *eventHub) close() {
+	close(h.done)
+}
+
+// watchDebounce groups the bursts of events editors produce when saving
+const watchDebounce = 100 * time.Millisecond
+
+// watchFiles watches resume.yaml, order.yaml and tags.yaml, reloading the
+// cache and publishing a ReloadEvent whenever they change. The directory is
+// watched so files replaced by rename are seen, and the files themselves so
+// single-file bind mounts are.
+func (s *Server) watchFiles() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{s.resumePath, s.orderPath(), resume.TaxonomyPath(s.resumePath)}
+	watched := make(map[string]string)
+	for _, path := range paths {
+		watched[filepath.Base(path)] = path
+		// Missing files are picked up by the directory watch once created
+		watcher.Add(path)
+	}
+	if err := watcher.Add(filepath.Dir(s.resumePath)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		changed := make(map[string]bool)
+		timer := time.NewTimer(watchDebounce)
+		timer.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				name := filepath.Base(event.Name)
+				path, ok := watched[name]
+				if !ok || event.Op == fsnotify.Chmod {
+					continue
+				}
+				if event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove) {
+					// A replaced file is a new inode; watch it again
+					watcher.Add(path)
+				}
+				changed[name] = true
+				timer.Reset(watchDebounce)
+			case <-timer.C:
+				files := make([]string, 0, len(changed))
+				for _, path := range paths {
+					if name := filepath.Base(path); changed[name] {
+						files = append(files, name)
+					}
+				}
+				clear(changed)
+				ev := s.reloadStatus(true)
+				ev.Files = files
+				if ev.OK {
+					log.Printf("Reloaded after change to %v", files)
+				} else {
+					log.Printf("Reload after change to %v failed: %s", files, ev.Error)
+				}
+				s.events.publish(ev)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("File watcher error: %v", err)
+			}
+		}
+	}()
+	return watcher, nil
+}
+
+// reloadStatus loads the watched files and reports whether they parse.
+// Unless force is set, the cached resume is used while it is up to date.
+func (s *Server) reloadStatus(force bool) ReloadEvent {
+	taxonomyPath := resume.TaxonomyPath(s.resumePath)
+	if _, err := resume.LoadTaxonomy(taxonomyPath); err != nil {
+		return parseFailure(taxonomyPath, err)
+	}
+	res, err := loadResume(force)
+	if err != nil {
+		return parseFailure(s.resumePath, err)
+	}
+	if _, err := resume.LoadOrder(s.orderPath(), res); err != nil {
+		return parseFailure(s.orderPath(), err)
+	}
+
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	return ReloadEvent{OK: true, ETag: cache.etag}
+}
+
+// yamlLine finds the line number yaml.v3 includes in its error messages
+var yamlLine = regexp.MustCompile(`line (\d+)`)
+
+func parseFailure(path string, err error) ReloadEvent {
+	ev := ReloadEvent{File: filepath.Base(path), Error: err.Error()}
+	if m := yamlLine.FindStringSubmatch(ev.Error); m != nil {
+		ev.Line, _ = strconv.Atoi(m[1])
+	}
+	return ev
+}
+
+// sseKeepAlive is how often an idle event stream gets a comment, so proxies
+// do not close it
+const sseKeepAlive = 30 * time.Second
+
+// handleEvents streams ReloadEvents as Server-Sent Events. The current
+// status is sent first, then one "reload" event per change.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Error disabling write deadline: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	send := func(event string, ev ReloadEvent) error {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	// Connecting does not force a reload; the watcher reloads on changes
+	if err := send("status", s.reloadStatus(false)); err != nil {
+		log.Printf("Error sending event: %v", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case ev := <-ch:
+			if err := send("reload", ev); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-s.events.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestReloadStatusUsesCache(t *testing.T) {
+	s, _ := newEditServer(t)
+	if w := send(s, http.MethodGet, "/api/resume", "", ""); w.Code != http.StatusOK {
+		t.Fatalf("GET /api/resume = %d: %s", w.Code, w.Body)
+	}
+	cached := cache.resume
+
+	if ev := s.reloadStatus(false); !ev.OK {
+		t.Fatalf("reloadStatus(false) failed: %s", ev.Error)
+	}
+	if cache.resume != cached {
+		t.Error("the status for a new client should not reload an up-to-date resume")
+	}
+	if ev := s.reloadStatus(true); !ev.OK || cache.resume == cached {
+		t.Error("a change should reload the resume")
+	}
+}
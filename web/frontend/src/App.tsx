@@ -4,10 +4,10 @@ import { useResume } from './hooks/useResume';
 import { Header } from './components/layout/Header';
 import { Sidebar } from './components/layout/Sidebar';
 import { ResumeEditor } from './components/resume/ResumeEditor';
-import { fetchResume } from './services/api';
+import { fetchResume, subscribeToChanges } from './services/api';
 
 const AppContent = () => {
-  const { dispatch } = useResume();
+  const { state, dispatch } = useResume();
 
   useEffect(() => {
     const loadResume = async () => {
@@ -26,6 +26,27 @@ const AppContent = () => {
     loadResume();
   }, [dispatch]);
 
+  // Pick up edits made to resume.yaml outside the browser
+  useEffect(
+    () =>
+      subscribeToChanges(async (event) => {
+        if (!event.ok) {
+          dispatch({ type: 'SET_FILE_ERROR', payload: event });
+          return;
+        }
+        dispatch({ type: 'SET_FILE_ERROR', payload: null });
+        if (event.files?.length) {
+          try {
+            dispatch({ type: 'MERGE_RESUME', payload: await fetchResume() });
+          } catch (error) {
+            const message = error instanceof Error ? error.message : 'Failed to reload resume';
+            dispatch({ type: 'SET_ERROR', payload: message });
+          }
+        }
+      }),
+    [dispatch]
+  );
+
   return (
     <div className="min-h-screen bg-gray-50">
       <Header />
@@ -35,6 +56,15 @@ const AppContent = () => {
         </div>
         <div className="flex-1 overflow-y-auto">
           <main className="max-w-5xl mx-auto px-6 py-8">
+            {state.fileError && (
+              <div className="mb-6 rounded-md border border-red-300 bg-red-50 px-4 py-3 text-sm text-red-800">
+                <p className="font-medium">
+                  {state.fileError.file}
+                  {state.fileError.line ? ` line ${state.fileError.line}` : ''} has an error; showing the last version that loaded
+                </p>
+                <pre className="mt-1 whitespace-pre-wrap font-mono text-xs">{state.fileError.error}</pre>
+              </div>
+            )}
             <ResumeEditor />
           </main>
         </div>
@@ -0,0 +1,21 @@
+interface VariantSelectProps {
+  variants: Record<string, string>;
+  value?: string;
+  onChange: (variant: string | undefined) => void;
+  className?: string;
+}
+
+export const VariantSelect = ({ variants, value, onChange, className = '' }: VariantSelectProps) => {
+  return (
+    <select
+      value={value ?? ''}
+      onChange={(e) => onChange(e.target.value || undefined)}
+      className={`text-xs border-gray-300 rounded text-gray-700 focus:ring-indigo-500 ${className}`}
+    >
+      <option value="">default</option>
+      {Object.keys(variants).sort().map((name) => (
+        <option key={name} value={name}>{name}</option>
+      ))}
+    </select>
+  );
+};
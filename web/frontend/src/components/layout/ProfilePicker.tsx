@@ -0,0 +1,108 @@
+import { useEffect, useState } from 'react';
+import { Button } from '../common/Button';
+import { useResume } from '../../hooks/useResume';
+import { deleteProfile, fetchProfiles, saveProfile } from '../../services/api';
+import { buildSelections } from '../../services/selections';
+import type { Profile } from '../../types/resume';
+
+interface ProfilePickerProps {
+  variant: string;
+  onVariantChange: (variant: string) => void;
+}
+
+export const ProfilePicker = ({ variant, onVariantChange }: ProfilePickerProps) => {
+  const { state, dispatch } = useResume();
+  const { resume } = state;
+  const [profiles, setProfiles] = useState<Profile[]>([]);
+  const [name, setName] = useState('');
+
+  useEffect(() => {
+    fetchProfiles()
+      .then(setProfiles)
+      .catch((error) => {
+        const message = error instanceof Error ? error.message : 'Failed to load profiles';
+        dispatch({ type: 'SET_ERROR', payload: message });
+      });
+  }, [dispatch]);
+
+  const handleApply = (profileName: string) => {
+    setName(profileName);
+    const profile = profiles.find((p) => p.name === profileName);
+    if (!profile) return;
+    dispatch({
+      type: 'APPLY_PROFILE',
+      payload: { selectedIds: profile.selected_ids, bulletVariants: profile.bullet_variants },
+    });
+    onVariantChange(profile.variant ?? '');
+  };
+
+  const handleSave = async () => {
+    if (!resume || !name.trim()) return;
+    const selections = buildSelections(resume);
+    const existing = profiles.find((p) => p.name === name);
+
+    try {
+      const saved = await saveProfile(name, {
+        description: existing?.description,
+        // Entry IDs are left out: a saved entry ID selects all of its bullets
+        ids: [...selections.bullet_ids, ...selections.leadership_ids, ...selections.custom_item_ids],
+        skills: selections.skill_ids,
+        order: existing?.order,
+        template: existing?.template,
+        variant: variant || undefined,
+      });
+      setProfiles([...profiles.filter((p) => p.name !== saved.name), saved].sort((a, b) => a.name.localeCompare(b.name)));
+      dispatch({ type: 'SET_ERROR', payload: null });
+    } catch (error) {
+      const message = error instanceof Error ? error.message : 'Failed to save profile';
+      dispatch({ type: 'SET_ERROR', payload: message });
+    }
+  };
+
+  const handleDelete = async () => {
+    if (!profiles.some((p) => p.name === name)) return;
+    try {
+      await deleteProfile(name);
+      setProfiles(profiles.filter((p) => p.name !== name));
+      setName('');
+    } catch (error) {
+      const message = error instanceof Error ? error.message : 'Failed to delete profile';
+      dispatch({ type: 'SET_ERROR', payload: message });
+    }
+  };
+
+  return (
+    <div className="pt-4 border-t space-y-2">
+      <h3 className="text-sm font-semibold text-gray-700 mb-2">Profiles</h3>
+      {profiles.length > 0 && (
+        <select
+          value={profiles.some((p) => p.name === name) ? name : ''}
+          onChange={(e) => handleApply(e.target.value)}
+          className="block w-full text-sm border-gray-300 rounded-md focus:ring-indigo-500"
+        >
+          <option value="" disabled>Load a profile…</option>
+          {profiles.map((profile) => (
+            <option key={profile.name} value={profile.name}>
+              {profile.name}{profile.description ? ` - ${profile.description}` : ''}
+            </option>
+          ))}
+        </select>
+      )}
+      <input
+        type="text"
+        value={name}
+        onChange={(e) => setName(e.target.value)}
+        placeholder="profile name, e.g. backend"
+        className="w-full px-3 py-2 text-sm border border-gray-300 rounded-md shadow-sm focus:outline-none focus:ring-indigo-500 focus:border-indigo-500"
+      />
+      <div className="flex gap-2">
+        <Button onClick={handleSave} variant="secondary" disabled={!resume || !name.trim()} className="flex-1">
+          Save
+        </Button>
+        <Button onClick={handleDelete} variant="danger" disabled={!profiles.some((p) => p.name === name)} className="flex-1">
+          Delete
+        </Button>
+      </div>
+    </div>
+  );
+};
@@ -1,12 +1,37 @@
+import { useState } from 'react';
 import { Button } from '../common/Button';
 import { JobInput } from '../job/JobInput';
 import { KeywordBadges } from '../job/KeywordBadges';
 import { useResume } from '../../hooks/useResume';
-import { generatePdf } from '../../services/api';
+import { generateResume } from '../../services/api';
+import { buildSelections } from '../../services/selections';
+import { ProfilePicker } from './ProfilePicker';
+
+type OutputFormat = 'pdf' | 'md' | 'txt' | 'html' | 'docx';
+
+const formatLabels: Record<OutputFormat, string> = {
+  pdf: 'PDF',
+  md: 'Markdown',
+  txt: 'Plain text (ATS)',
+  html: 'HTML',
+  docx: 'Word (DOCX)',
+};
 
 export const Sidebar = () => {
   const { state, dispatch } = useResume();
   const { jobAnalysis, resume, error } = state;
+  const [variant, setVariant] = useState('');
+  const [format, setFormat] = useState<OutputFormat>('pdf');
+
+  const variantNames = resume
+    ? Array.from(
+        new Set(
+          [...resume.experience, ...resume.projects]
+            .flatMap((entry) => entry.bullets)
+            .flatMap((bullet) => Object.keys(bullet.variants ?? {}))
+        )
+      ).sort()
+    : [];
 
   const handleSelectAll = () => {
     dispatch({ type: 'SELECT_ALL' });
@@ -20,53 +45,37 @@ export const Sidebar = () => {
     dispatch({ type: 'APPLY_SUGGESTIONS', payload: { threshold: 70 } });
   };
 
-  const handleGeneratePdf = async () => {
+  const handleGenerate = async () => {
     if (!resume) return;
 
     try {
-      const selectedSkills = resume.skills
-        .flatMap((cat) => cat.items.filter((s) => s.selected).map((s) => s.name));
-      const selectedBullets: string[] = [];
-      const selectedExperience: string[] = [];
-      const selectedProjects: string[] = [];
-
-      resume.experience.forEach((entry) => {
-        if (entry.selected) {
-          selectedExperience.push(entry.id);
-          entry.bullets.filter((b) => b.selected).forEach((b) => selectedBullets.push(b.id));
-        }
-      });
-
-      resume.projects.forEach((entry) => {
-        if (entry.selected) {
-          selectedProjects.push(entry.id);
-          entry.bullets.filter((b) => b.selected).forEach((b) => selectedBullets.push(b.id));
-        }
-      });
-
-      const selectedLeadership = resume.leadership
-        .filter((l) => l.selected)
-        .map((l) => l.id);
-
-      const selections = {
-        skill_ids: selectedSkills,
-        experience_ids: selectedExperience,
-        bullet_ids: selectedBullets,
-        project_ids: selectedProjects,
-        leadership_ids: selectedLeadership,
-      };
-
-      const blob = await generatePdf({ selections });
+      const selections = buildSelections(resume);
+
+      const blob = await generateResume({ selections, variant: variant || undefined, format });
       const url = window.URL.createObjectURL(blob);
       const a = document.createElement('a');
       a.href = url;
-      a.download = `${resume.contact.name.replace(/\s+/g, '_')}_Resume.pdf`;
+      a.download = `${resume.contact.name.replace(/\s+/g, '_')}_Resume.${format}`;
       document.body.appendChild(a);
       a.click();
       window.URL.revokeObjectURL(url);
       document.body.removeChild(a);
     } catch (error) {
-      const message = error instanceof Error ? error.message : 'Failed to generate PDF';
+      const message = error instanceof Error ? error.message : `Failed to generate ${formatLabels[format]}`;
+      dispatch({ type: 'SET_ERROR', payload: message });
+    }
+  };
+
+  // handlePreview opens the selection as a web page in a new tab, skipping xelatex
+  const handlePreview = async () => {
+    if (!resume) return;
+
+    try {
+      const selections = buildSelections(resume);
+      const blob = await generateResume({ selections, variant: variant || undefined, format: 'html' });
+      window.open(window.URL.createObjectURL(blob), '_blank');
+    } catch (error) {
+      const message = error instanceof Error ? error.message : 'Failed to render preview';
       dispatch({ type: 'SET_ERROR', payload: message });
     }
   };
@@ -98,6 +107,8 @@ export const Sidebar = () => {
           </div>
         )}
 
+        <ProfilePicker variant={variant} onVariantChange={setVariant} />
+
         <div className="pt-4 border-t space-y-2">
           <h3 className="text-sm font-semibold text-gray-700 mb-2">Controls</h3>
           <Button onClick={handleSelectAll} variant="secondary" className="w-full">
@@ -108,13 +119,44 @@ export const Sidebar = () => {
           </Button>
         </div>
 
-        <div className="pt-4 border-t">
+        <div className="pt-4 border-t space-y-2">
+          {variantNames.length > 0 && (
+            <label className="block text-sm text-gray-700">
+              Bullet wording
+              <select
+                value={variant}
+                onChange={(e) => setVariant(e.target.value)}
+                className="mt-1 block w-full text-sm border-gray-300 rounded-md focus:ring-indigo-500"
+              >
+                <option value="">default</option>
+                <option value="auto">auto (short if over one page)</option>
+                {variantNames.map((name) => (
+                  <option key={name} value={name}>{name}</option>
+                ))}
+              </select>
+            </label>
+          )}
+          <label className="block text-sm text-gray-700">
+            Format
+            <select
+              value={format}
+              onChange={(e) => setFormat(e.target.value as OutputFormat)}
+              className="mt-1 block w-full text-sm border-gray-300 rounded-md focus:ring-indigo-500"
+            >
+              {(Object.keys(formatLabels) as OutputFormat[]).map((name) => (
+                <option key={name} value={name}>{formatLabels[name]}</option>
+              ))}
+            </select>
+          </label>
           <Button
-            onClick={handleGeneratePdf}
+            onClick={handleGenerate}
             disabled={!resume}
             className="w-full"
           >
-            Generate PDF
+            Generate {formatLabels[format]}
+          </Button>
+          <Button onClick={handlePreview} disabled={!resume} variant="secondary" className="w-full">
+            Preview
           </Button>
         </div>
       </div>
@@ -0,0 +1,74 @@
+import { useState } from 'react';
+import { Checkbox } from '../common/Checkbox';
+import { useResume } from '../../hooks/useResume';
+import type { CustomItem, CustomSection as CustomSectionData } from '../../types/resume';
+
+interface CustomSectionProps {
+  section: CustomSectionData;
+}
+
+const renderItem = (item: CustomItem, layout: CustomSectionData['layout']) => {
+  switch (layout) {
+    case 'entries':
+      return (
+        <div className="flex-1">
+          <div className="flex justify-between items-start">
+            <p className="font-medium text-gray-900">
+              {item.title}
+              {item.subtitle && <span className="font-normal text-gray-600"> · {item.subtitle}</span>}
+            </p>
+            {item.date && <span className="text-gray-600 text-sm">{item.date}</span>}
+          </div>
+          {item.text && <p className="text-sm text-gray-700">{item.text}</p>}
+        </div>
+      );
+    case 'table':
+      return (
+        <p className="text-sm flex-1">
+          <span className="font-medium text-gray-900">{item.key}:</span>{' '}
+          <span className="text-gray-700">{item.value}</span>
+        </p>
+      );
+    default:
+      return <p className="text-sm flex-1 text-gray-700">{item.text}</p>;
+  }
+};
+
+export const CustomSection = ({ section }: CustomSectionProps) => {
+  const { dispatch } = useResume();
+  const [isExpanded, setIsExpanded] = useState(true);
+
+  const handleToggle = (itemId: string) => {
+    dispatch({ type: 'TOGGLE_CUSTOM_ITEM', payload: { sectionId: section.id, itemId } });
+  };
+
+  return (
+    <div className="bg-white rounded-lg shadow-sm p-6">
+      <button
+        onClick={() => setIsExpanded(!isExpanded)}
+        className="w-full flex justify-between items-center mb-4"
+      >
+        <h2 className="text-xl font-bold text-gray-900">{section.title}</h2>
+        <span className="text-gray-500">{isExpanded ? '−' : '+'}</span>
+      </button>
+
+      {isExpanded && (
+        <ul className="space-y-3">
+          {section.items.map((item) => (
+            <li
+              key={item.id}
+              className={`flex items-start gap-2 ${item.selected ? '' : 'line-through opacity-50'}`}
+            >
+              <Checkbox
+                checked={item.selected}
+                onChange={() => handleToggle(item.id)}
+                className="mt-1"
+              />
+              {renderItem(item, section.layout)}
+            </li>
+          ))}
+        </ul>
+      )}
+    </div>
+  );
+};
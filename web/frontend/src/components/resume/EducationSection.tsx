@@ -1,30 +1,48 @@
 import type { EducationEntry } from '../../types/resume';
 
 interface EducationSectionProps {
-  education: EducationEntry;
+  education: EducationEntry[];
 }
 
 export const EducationSection = ({ education }: EducationSectionProps) => {
   return (
     <div className="bg-white rounded-lg shadow-sm p-6">
       <h2 className="text-xl font-bold text-gray-900 mb-4">Education</h2>
-      <div className="space-y-2">
-        <div className="flex justify-between items-start">
-          <div>
-            <h3 className="font-semibold text-gray-900">{education.institution}</h3>
-            <p className="text-gray-700">{education.degree}</p>
+      <div className="space-y-4">
+        {education.map((entry) => (
+          <div key={entry.id} className="space-y-2">
+            <div className="flex justify-between items-start">
+              <div>
+                <h3 className="font-semibold text-gray-900">{entry.institution}</h3>
+                <p className="text-gray-700">{entry.degree}</p>
+              </div>
+              <div className="text-right">
+                <p className="text-gray-600 text-sm">{entry.location}</p>
+                {entry.end_date && (
+                  <p className="text-gray-600 text-sm">
+                    {entry.start_date ? `${entry.start_date} – ${entry.end_date}` : entry.end_date}
+                  </p>
+                )}
+              </div>
+            </div>
+            {entry.minor && (
+              <p className="text-gray-600 text-sm">Minor: {entry.minor}</p>
+            )}
+            {entry.gpa && (
+              <p className="text-gray-600 text-sm">GPA: {entry.gpa}</p>
+            )}
+            {entry.honors && (
+              <p className="text-gray-600 text-sm">{entry.honors}</p>
+            )}
+            {entry.coursework.length > 0 && (
+              <ul className="list-disc list-inside text-gray-600 text-sm">
+                {entry.coursework.map((course) => (
+                  <li key={course.id}>{course.text}</li>
+                ))}
+              </ul>
+            )}
           </div>
-          <p className="text-gray-600 text-sm">{education.location}</p>
-        </div>
-        {education.minor && (
-          <p className="text-gray-600 text-sm">Minor: {education.minor}</p>
-        )}
-        {education.gpa && (
-          <p className="text-gray-600 text-sm">GPA: {education.gpa}</p>
-        )}
-        {education.honors && (
-          <p className="text-gray-600 text-sm">{education.honors}</p>
-        )}
+        ))}
       </div>
     </div>
   );
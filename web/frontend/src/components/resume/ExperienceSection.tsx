@@ -2,12 +2,13 @@ import { useState } from 'react';
 import { DndContext, closestCenter } from '@dnd-kit/core';
 import { SortableContext, verticalListSortingStrategy } from '@dnd-kit/sortable';
 import { Checkbox } from '../common/Checkbox';
+import { VariantSelect } from '../common/VariantSelect';
 import { RelevanceBar } from '../job/RelevanceBar';
 import { useResume } from '../../hooks/useResume';
 import { useSectionReorder } from '../../hooks/useSectionReorder';
 import { SortableItem } from '../dnd/SortableItem';
 import { DragHandle } from '../dnd/DragHandle';
-import type { ExperienceEntry } from '../../types/resume';
+import type { Bullet, ExperienceEntry } from '../../types/resume';
 
 interface ExperienceSectionProps {
   experiences: ExperienceEntry[];
@@ -37,6 +38,37 @@ export const ExperienceSection = ({ experiences }: ExperienceSectionProps) => {
     dispatch({ type: 'TOGGLE_BULLET', payload: { entryId, bulletId, entryType: 'experience' } });
   };
 
+  const handleSetVariant = (entryId: string, bulletId: string, variant?: string) => {
+    dispatch({ type: 'SET_BULLET_VARIANT', payload: { entryId, bulletId, entryType: 'experience', variant } });
+  };
+
+  const renderBullet = (exp: ExperienceEntry, bullet: Bullet) => (
+    <li key={bullet.id} className="flex items-start gap-2">
+      <Checkbox
+        checked={bullet.selected}
+        onChange={() => handleToggleBullet(exp.id, bullet.id)}
+        className="mt-1"
+      />
+      <div className="flex-1">
+        <p className={`text-sm ${bullet.selected ? 'text-gray-700' : 'text-gray-500 line-through'}`}>
+          {(bullet.variant && bullet.variants?.[bullet.variant]) || bullet.text}
+        </p>
+      </div>
+      {bullet.variants && (
+        <VariantSelect
+          variants={bullet.variants}
+          value={bullet.variant}
+          onChange={(variant) => handleSetVariant(exp.id, bullet.id, variant)}
+        />
+      )}
+      {jobAnalysis && bullet.relevanceScore !== undefined && (
+        <div className="ml-2">
+          <RelevanceBar score={bullet.relevanceScore} />
+        </div>
+      )}
+    </li>
+  );
+
   return (
     <div className="bg-white rounded-lg shadow-sm p-6">
       <h2 className="text-xl font-bold text-gray-900 mb-4">Experience</h2>
@@ -45,7 +77,7 @@ export const ExperienceSection = ({ experiences }: ExperienceSectionProps) => {
           <div className="space-y-4">
             {experiences.map((exp) => {
               const isExpanded = expandedIds.has(exp.id);
-              const dateRange = `${exp.start_date} - ${exp.end_date || 'Present'}`;
+              const dateRange = `${exp.start_date} - ${exp.end_date || 'Present'}${exp.duration ? ` · ${exp.duration}` : ''}`;
 
               return (
                 <SortableItem key={exp.id} id={exp.id}>
@@ -84,27 +116,32 @@ export const ExperienceSection = ({ experiences }: ExperienceSectionProps) => {
                             </div>
                           </button>
 
-                          {isExpanded && (
-                            <ul className="mt-3 space-y-2">
-                              {exp.bullets.map((bullet) => (
-                                <li key={bullet.id} className="flex items-start gap-2">
-                                  <Checkbox
-                                    checked={bullet.selected}
-                                    onChange={() => handleToggleBullet(exp.id, bullet.id)}
-                                    className="mt-1"
-                                  />
-                                  <div className="flex-1">
-                                    <p className={`text-sm ${bullet.selected ? 'text-gray-700' : 'text-gray-500 line-through'}`}>
-                                      {bullet.text}
-                                    </p>
+                          {isExpanded && exp.positions.length > 1 && (
+                            <div className="mt-3 space-y-3">
+                              {exp.positions.map((position) => (
+                                <div key={position.id}>
+                                  <div className="flex justify-between text-sm">
+                                    <span className="font-medium text-gray-800">
+                                      {position.title}{position.location ? `, ${position.location}` : ''}
+                                    </span>
+                                    <span className="text-gray-600">
+                                      {position.start_date} - {position.end_date || 'Present'}
+                                      {position.duration ? ` · ${position.duration}` : ''}
+                                    </span>
                                   </div>
-                                  {jobAnalysis && bullet.relevanceScore !== undefined && (
-                                    <div className="ml-2">
-                                      <RelevanceBar score={bullet.relevanceScore} />
-                                    </div>
-                                  )}
-                                </li>
+                                  <ul className="mt-2 space-y-2">
+                                    {exp.bullets
+                                      .filter((bullet) => position.bullet_ids.includes(bullet.id))
+                                      .map((bullet) => renderBullet(exp, bullet))}
+                                  </ul>
+                                </div>
                               ))}
+                            </div>
+                          )}
+
+                          {isExpanded && exp.positions.length <= 1 && (
+                            <ul className="mt-3 space-y-2">
+                              {exp.bullets.map((bullet) => renderBullet(exp, bullet))}
                             </ul>
                           )}
                         </div>
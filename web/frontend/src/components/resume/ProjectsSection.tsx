@@ -2,6 +2,7 @@ import { useState } from 'react';
 import { DndContext, closestCenter } from '@dnd-kit/core';
 import { SortableContext, verticalListSortingStrategy } from '@dnd-kit/sortable';
 import { Checkbox } from '../common/Checkbox';
+import { VariantSelect } from '../common/VariantSelect';
 import { RelevanceBar } from '../job/RelevanceBar';
 import { useResume } from '../../hooks/useResume';
 import { useSectionReorder } from '../../hooks/useSectionReorder';
@@ -37,6 +38,10 @@ export const ProjectsSection = ({ projects }: ProjectsSectionProps) => {
     dispatch({ type: 'TOGGLE_BULLET', payload: { entryId, bulletId, entryType: 'project' } });
   };
 
+  const handleSetVariant = (entryId: string, bulletId: string, variant?: string) => {
+    dispatch({ type: 'SET_BULLET_VARIANT', payload: { entryId, bulletId, entryType: 'project', variant } });
+  };
+
   return (
     <div className="bg-white rounded-lg shadow-sm p-6">
       <h2 className="text-xl font-bold text-gray-900 mb-4">Projects</h2>
@@ -100,9 +105,16 @@ export const ProjectsSection = ({ projects }: ProjectsSectionProps) => {
                                   />
                                   <div className="flex-1">
                                     <p className={`text-sm ${bullet.selected ? 'text-gray-700' : 'text-gray-500 line-through'}`}>
-                                      {bullet.text}
+                                      {(bullet.variant && bullet.variants?.[bullet.variant]) || bullet.text}
                                     </p>
                                   </div>
+                                  {bullet.variants && (
+                                    <VariantSelect
+                                      variants={bullet.variants}
+                                      value={bullet.variant}
+                                      onChange={(variant) => handleSetVariant(project.id, bullet.id, variant)}
+                                    />
+                                  )}
                                   {jobAnalysis && bullet.relevanceScore !== undefined && (
                                     <div className="ml-2">
                                       <RelevanceBar score={bullet.relevanceScore} />
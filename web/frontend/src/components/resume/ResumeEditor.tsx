@@ -4,6 +4,7 @@ import { ExperienceSection } from './ExperienceSection';
 import { ProjectsSection } from './ProjectsSection';
 import { EducationSection } from './EducationSection';
 import { LeadershipSection } from './LeadershipSection';
+import { CustomSection } from './CustomSection';
 
 export const ResumeEditor = () => {
   const { state } = useResume();
@@ -34,6 +35,9 @@ export const ResumeEditor = () => {
       <ExperienceSection experiences={resume.experience} />
       <ProjectsSection projects={resume.projects} />
       <LeadershipSection leadership={resume.leadership} />
+      {resume.custom_sections.map((section) => (
+        <CustomSection key={section.id} section={section} />
+      ))}
     </div>
   );
 };
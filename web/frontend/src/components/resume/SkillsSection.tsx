@@ -12,17 +12,17 @@ export const SkillsSection = ({ skills }: SkillsSectionProps) => {
   const [isExpanded, setIsExpanded] = useState(true);
   const { jobAnalysis } = state;
 
-  const getRelevanceColor = (skillName: string): string => {
+  const getRelevanceColor = (skillId: string): string => {
     if (!jobAnalysis) return '';
-    const score = jobAnalysis.scores[skillName] || 0;
+    const score = jobAnalysis.scores[skillId] || 0;
     if (score >= 70) return 'border-green-500 bg-green-50';
     if (score >= 40) return 'border-yellow-500 bg-yellow-50';
     if (score > 0) return 'border-red-500 bg-red-50';
     return '';
   };
 
-  const handleToggle = (category: string, skillName: string) => {
-    dispatch({ type: 'TOGGLE_SKILL', payload: { category, skillName } });
+  const handleToggle = (category: string, skillId: string) => {
+    dispatch({ type: 'TOGGLE_SKILL', payload: { category, skillId } });
   };
 
   const handleToggleCategory = (category: string, items: { selected: boolean }[]) => {
@@ -49,23 +49,23 @@ export const SkillsSection = ({ skills }: SkillsSectionProps) => {
                   checked={category.items.every((item) => item.selected)}
                   onChange={() => handleToggleCategory(category.category, category.items)}
                 />
-                <h3 className="text-sm font-semibold text-gray-700 capitalize">
-                  {category.category}
+                <h3 className="text-sm font-semibold text-gray-700">
+                  {category.label}
                 </h3>
               </div>
               <div className="flex flex-wrap gap-2">
                 {category.items.map((skill) => (
                   <div
-                    key={skill.name}
+                    key={skill.id}
                     className={`inline-flex items-center border rounded-full px-3 py-1 transition-all ${
                       skill.selected
-                        ? `border-indigo-600 bg-indigo-50 ${getRelevanceColor(skill.name)}`
+                        ? `border-indigo-600 bg-indigo-50 ${getRelevanceColor(skill.id)}`
                         : 'border-gray-300 bg-white opacity-50'
                     }`}
                   >
                     <Checkbox
                       checked={skill.selected}
-                      onChange={() => handleToggle(category.category, skill.name)}
+                      onChange={() => handleToggle(category.category, skill.id)}
                     />
                     <span className={`ml-2 text-sm ${skill.selected ? 'text-gray-900' : 'text-gray-500 line-through'}`}>
                       {skill.name}
@@ -1,25 +1,32 @@
 import { createContext, useReducer, ReactNode } from 'react';
-import type { Resume, JobAnalysisResponse, SectionName } from '../types/resume';
+import type { Bullet, Resume, JobAnalysisResponse, ReloadEvent, SectionName } from '../types/resume';
 
 interface ResumeState {
   resume: Resume | null;
   jobAnalysis: JobAnalysisResponse | null;
   isLoading: boolean;
   error: string | null;
+  // fileError is set while a watched file on disk fails to parse
+  fileError: ReloadEvent | null;
 }
 
 type ResumeAction =
   | { type: 'SET_RESUME'; payload: Resume }
-  | { type: 'TOGGLE_SKILL'; payload: { category: string; skillName: string } }
+  | { type: 'MERGE_RESUME'; payload: Resume }
+  | { type: 'SET_FILE_ERROR'; payload: ReloadEvent | null }
+  | { type: 'TOGGLE_SKILL'; payload: { category: string; skillId: string } }
   | { type: 'TOGGLE_SKILL_CATEGORY'; payload: { category: string; selected: boolean } }
   | { type: 'TOGGLE_BULLET'; payload: { entryId: string; bulletId: string; entryType: 'experience' | 'project' } }
+  | { type: 'SET_BULLET_VARIANT'; payload: { entryId: string; bulletId: string; entryType: 'experience' | 'project'; variant?: string } }
   | { type: 'TOGGLE_EXPERIENCE'; payload: string }
   | { type: 'TOGGLE_PROJECT'; payload: string }
   | { type: 'TOGGLE_LEADERSHIP'; payload: string }
+  | { type: 'TOGGLE_CUSTOM_ITEM'; payload: { sectionId: string; itemId: string } }
   | { type: 'SELECT_ALL' }
   | { type: 'DESELECT_ALL' }
   | { type: 'SET_JOB_ANALYSIS'; payload: JobAnalysisResponse }
   | { type: 'APPLY_SUGGESTIONS'; payload: { threshold: number } }
+  | { type: 'APPLY_PROFILE'; payload: { selectedIds: string[]; bulletVariants?: Record<string, string> } }
   | { type: 'SET_LOADING'; payload: boolean }
   | { type: 'SET_ERROR'; payload: string | null }
   | { type: 'REORDER_SECTION'; payload: { section: SectionName; order: string[] } };
@@ -29,6 +36,7 @@ const initialState: ResumeState = {
   jobAnalysis: null,
   isLoading: false,
   error: null,
+  fileError: null,
 };
 
 function reorderSection<T extends { id: string }>(items: T[], order: string[]): T[] {
@@ -38,11 +46,72 @@ function reorderSection<T extends { id: string }>(items: T[], order: string[]):
   );
 }
 
+// mergeSelections carries selections and chosen variants over to a reloaded
+// resume; items that are new keep the server's defaults
+function mergeSelections(previous: Resume, next: Resume): Resume {
+  const selected = new Map<string, boolean>();
+  const variants = new Map<string, string | undefined>();
+  const record = (item: { id: string; selected: boolean }) => selected.set(item.id, item.selected);
+  const recordBullets = (bullets: Bullet[]) =>
+    bullets.forEach((bullet) => {
+      record(bullet);
+      variants.set(bullet.id, bullet.variant);
+    });
+
+  previous.skills.forEach((category) => category.items.forEach(record));
+  previous.experience.forEach((entry) => {
+    record(entry);
+    recordBullets(entry.bullets);
+  });
+  previous.projects.forEach((entry) => {
+    record(entry);
+    recordBullets(entry.bullets);
+  });
+  previous.education.forEach((entry) => {
+    record(entry);
+    recordBullets(entry.coursework);
+  });
+  previous.leadership.forEach(record);
+  previous.custom_sections.forEach((section) => section.items.forEach(record));
+
+  const apply = <T extends { id: string; selected: boolean }>(item: T): T => ({
+    ...item,
+    selected: selected.get(item.id) ?? item.selected,
+  });
+  const applyBullets = (bullets: Bullet[]) =>
+    bullets.map((bullet) => {
+      const variant = variants.get(bullet.id);
+      // Drop a chosen variant that no longer exists
+      const keep = variant && bullet.variants?.[variant] !== undefined;
+      return { ...apply(bullet), variant: keep ? variant : undefined };
+    });
+
+  return {
+    ...next,
+    skills: next.skills.map((category) => ({ ...category, items: category.items.map(apply) })),
+    experience: next.experience.map((entry) => ({ ...apply(entry), bullets: applyBullets(entry.bullets) })),
+    projects: next.projects.map((entry) => ({ ...apply(entry), bullets: applyBullets(entry.bullets) })),
+    education: next.education.map((entry) => ({ ...apply(entry), coursework: applyBullets(entry.coursework) })),
+    leadership: next.leadership.map(apply),
+    custom_sections: next.custom_sections.map((section) => ({ ...section, items: section.items.map(apply) })),
+  };
+}
+
 const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState => {
   switch (action.type) {
     case 'SET_RESUME':
       return { ...state, resume: action.payload, error: null };
 
+    case 'MERGE_RESUME':
+      return {
+        ...state,
+        resume: state.resume ? mergeSelections(state.resume, action.payload) : action.payload,
+        fileError: null,
+      };
+
+    case 'SET_FILE_ERROR':
+      return { ...state, fileError: action.payload };
+
     case 'SET_LOADING':
       return { ...state, isLoading: action.payload };
 
@@ -59,7 +128,7 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
           return {
             ...category,
             items: category.items.map((item) =>
-              item.name === action.payload.skillName
+              item.id === action.payload.skillId
                 ? { ...item, selected: !item.selected }
                 : item
             ),
@@ -120,6 +189,27 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
       }
     }
 
+    case 'SET_BULLET_VARIANT': {
+      if (!state.resume) return state;
+      const { entryId, bulletId, entryType, variant } = action.payload;
+      const setVariant = <T extends { id: string; bullets: Bullet[] }>(entries: T[]): T[] =>
+        entries.map((entry) =>
+          entry.id === entryId
+            ? {
+                ...entry,
+                bullets: entry.bullets.map((bullet) =>
+                  bullet.id === bulletId ? { ...bullet, variant } : bullet
+                ),
+              }
+            : entry
+        );
+
+      if (entryType === 'experience') {
+        return { ...state, resume: { ...state.resume, experience: setVariant(state.resume.experience) } };
+      }
+      return { ...state, resume: { ...state.resume, projects: setVariant(state.resume.projects) } };
+    }
+
     case 'TOGGLE_EXPERIENCE': {
       if (!state.resume) return state;
       const experience = state.resume.experience.map((entry) => {
@@ -160,6 +250,22 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
       return { ...state, resume: { ...state.resume, leadership } };
     }
 
+    case 'TOGGLE_CUSTOM_ITEM': {
+      if (!state.resume) return state;
+      const { sectionId, itemId } = action.payload;
+      const custom_sections = state.resume.custom_sections.map((section) =>
+        section.id === sectionId
+          ? {
+              ...section,
+              items: section.items.map((item) =>
+                item.id === itemId ? { ...item, selected: !item.selected } : item
+              ),
+            }
+          : section
+      );
+      return { ...state, resume: { ...state.resume, custom_sections } };
+    }
+
     case 'SELECT_ALL': {
       if (!state.resume) return state;
       const skills = state.resume.skills.map((category) => ({
@@ -177,9 +283,13 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
         bullets: entry.bullets.map((bullet) => ({ ...bullet, selected: true })),
       }));
       const leadership = state.resume.leadership.map((entry) => ({ ...entry, selected: true }));
+      const custom_sections = state.resume.custom_sections.map((section) => ({
+        ...section,
+        items: section.items.map((item) => ({ ...item, selected: true })),
+      }));
       return {
         ...state,
-        resume: { ...state.resume, skills, experience, projects, leadership },
+        resume: { ...state.resume, skills, experience, projects, leadership, custom_sections },
       };
     }
 
@@ -200,9 +310,13 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
         bullets: entry.bullets.map((bullet) => ({ ...bullet, selected: false })),
       }));
       const leadership = state.resume.leadership.map((entry) => ({ ...entry, selected: false }));
+      const custom_sections = state.resume.custom_sections.map((section) => ({
+        ...section,
+        items: section.items.map((item) => ({ ...item, selected: false })),
+      }));
       return {
         ...state,
-        resume: { ...state.resume, skills, experience, projects, leadership },
+        resume: { ...state.resume, skills, experience, projects, leadership, custom_sections },
       };
     }
 
@@ -214,7 +328,7 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
       const skills = state.resume.skills.map((category) => ({
         ...category,
         items: category.items.map((item) => {
-          const score = scores[item.name] || 0;
+          const score = scores[item.id] || 0;
           return { ...item, selected: score >= threshold };
         }),
       }));
@@ -242,9 +356,64 @@ const resumeReducer = (state: ResumeState, action: ResumeAction): ResumeState =>
         return { ...entry, selected: score >= threshold };
       });
 
+      const custom_sections = state.resume.custom_sections.map((section) => ({
+        ...section,
+        items: section.items.map((item) => {
+          const score = scores[item.id] || 0;
+          return { ...item, selected: score >= threshold, relevanceScore: score };
+        }),
+      }));
+
+      return {
+        ...state,
+        resume: { ...state.resume, skills, experience, projects, leadership, custom_sections },
+      };
+    }
+
+    case 'APPLY_PROFILE': {
+      if (!state.resume) return state;
+      const { selectedIds, bulletVariants = {} } = action.payload;
+      // An empty selection means the profile includes everything
+      const ids = new Set(selectedIds);
+      const isSelected = (id: string) => ids.size === 0 || ids.has(id);
+      const applyBullets = (bullets: Bullet[]) =>
+        bullets.map((bullet) => ({
+          ...bullet,
+          selected: isSelected(bullet.id),
+          variant: bulletVariants[bullet.id],
+        }));
+
+      // Skills and custom sections are only filtered when the profile names one of their items
+      const allSkills = state.resume.skills.flatMap((category) => category.items);
+      const filterSkills = allSkills.some((item) => ids.has(item.id));
+      const skills = state.resume.skills.map((category) => ({
+        ...category,
+        items: category.items.map((item) => ({ ...item, selected: !filterSkills || ids.has(item.id) })),
+      }));
+
+      const experience = state.resume.experience.map((entry) => {
+        const bullets = applyBullets(entry.bullets);
+        return { ...entry, bullets, selected: bullets.some((b) => b.selected) };
+      });
+
+      const projects = state.resume.projects.map((entry) => {
+        const bullets = applyBullets(entry.bullets);
+        return { ...entry, bullets, selected: bullets.some((b) => b.selected) };
+      });
+
+      const leadership = state.resume.leadership.map((entry) => ({ ...entry, selected: isSelected(entry.id) }));
+
+      const custom_sections = state.resume.custom_sections.map((section) => {
+        const filterItems = section.items.some((item) => ids.has(item.id));
+        return {
+          ...section,
+          items: section.items.map((item) => ({ ...item, selected: !filterItems || ids.has(item.id) })),
+        };
+      });
+
       return {
         ...state,
-        resume: { ...state.resume, skills, experience, projects, leadership },
+        resume: { ...state.resume, skills, experience, projects, leadership, custom_sections },
       };
     }
 
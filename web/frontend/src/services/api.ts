@@ -1,5 +1,18 @@
 import axios from 'axios';
-import type { Resume, JobAnalysisResponse, PartialSectionOrder } from '../types/resume';
+import type {
+  Resume,
+  JobAnalysisResponse,
+  PartialSectionOrder,
+  Profile,
+  ProfileInput,
+  ContactInfo,
+  BulletInput,
+  ExperienceInput,
+  ProjectInput,
+  LeadershipInput,
+  SkillInput,
+  ReloadEvent,
+} from '../types/resume';
 
 const api = axios.create({
   baseURL: '',
@@ -8,11 +21,65 @@ const api = axios.create({
   },
 });
 
+// resumeETag is the version of resume.yaml the UI last loaded. Edits send it
+// in If-Match so the server rejects them if the file changed underneath.
+let resumeETag: string | undefined;
+
 export const fetchResume = async (): Promise<Resume> => {
   const response = await api.get<Resume>('/api/resume');
+  resumeETag = response.headers['etag'];
+  return response.data;
+};
+
+// subscribeToChanges calls onEvent with the current file status and again
+// whenever the watched files change. It returns a function that unsubscribes.
+export const subscribeToChanges = (onEvent: (event: ReloadEvent) => void): (() => void) => {
+  const source = new EventSource('/api/events');
+  const handle = (message: MessageEvent<string>) => onEvent(JSON.parse(message.data) as ReloadEvent);
+  source.addEventListener('status', handle);
+  source.addEventListener('reload', handle);
+  return () => source.close();
+};
+
+const editResume = async (method: 'post' | 'put' | 'patch' | 'delete', url: string, data?: unknown): Promise<Resume> => {
+  const response = await api.request<Resume>({
+    method,
+    url,
+    data,
+    headers: { 'If-Match': resumeETag ?? '' },
+  });
+  resumeETag = response.headers['etag'];
   return response.data;
 };
 
+const itemURL = (kind: string, id: string) => `/api/resume/${kind}/${encodeURIComponent(id)}`;
+
+export const updateContact = (contact: Partial<ContactInfo>) => editResume('put', '/api/resume/contact', contact);
+export const updateSummary = (summary: string) => editResume('put', '/api/resume/summary', { summary });
+
+export const createExperience = (input: ExperienceInput) => editResume('post', '/api/resume/experience', input);
+export const updateExperience = (id: string, input: ExperienceInput) => editResume('put', itemURL('experience', id), input);
+export const deleteExperience = (id: string) => editResume('delete', itemURL('experience', id));
+
+export const createProject = (input: ProjectInput) => editResume('post', '/api/resume/projects', input);
+export const updateProject = (id: string, input: ProjectInput) => editResume('put', itemURL('projects', id), input);
+export const deleteProject = (id: string) => editResume('delete', itemURL('projects', id));
+
+export const createBullet = (input: BulletInput) => editResume('post', '/api/resume/bullets', input);
+export const updateBullet = (id: string, input: BulletInput) => editResume('put', itemURL('bullets', id), input);
+export const deleteBullet = (id: string) => editResume('delete', itemURL('bullets', id));
+
+export const createLeadership = (input: LeadershipInput) => editResume('post', '/api/resume/leadership', input);
+export const updateLeadership = (id: string, input: LeadershipInput) => editResume('put', itemURL('leadership', id), input);
+export const deleteLeadership = (id: string) => editResume('delete', itemURL('leadership', id));
+
+export const createSkill = (input: SkillInput) => editResume('post', '/api/resume/skills', input);
+export const updateSkill = (id: string, input: SkillInput) => editResume('put', itemURL('skills', id), input);
+export const deleteSkill = (id: string) => editResume('delete', itemURL('skills', id));
+
+export const updateTags = (id: string, add: string[], remove: string[] = []) =>
+  editResume('patch', `${itemURL('items', id)}/tags`, { add, remove });
+
 export const analyzeJob = async (
   jobTitle: string,
   company: string,
@@ -26,8 +93,9 @@ export const analyzeJob = async (
   return response.data;
 };
 
-export const generatePdf = async (selections: Record<string, unknown>): Promise<Blob> => {
-  const response = await api.post('/api/generate', selections, {
+// generateResume renders the selection; body.format picks pdf (the default), md, txt, html or docx
+export const generateResume = async (body: Record<string, unknown>): Promise<Blob> => {
+  const response = await api.post('/api/generate', body, {
     responseType: 'blob',
   });
   return response.data;
@@ -36,3 +104,22 @@ export const generatePdf = async (selections: Record<string, unknown>): Promise<
 export const saveOrder = async (order: PartialSectionOrder): Promise<void> => {
   await api.put('/api/order', order);
 };
+
+export const filterItems = async (query: string): Promise<string[]> => {
+  const response = await api.get<{ query: string; ids: string[] }>('/api/filter', { params: { query } });
+  return response.data.ids;
+};
+
+export const fetchProfiles = async (): Promise<Profile[]> => {
+  const response = await api.get<Profile[]>('/api/profiles');
+  return response.data;
+};
+
+export const saveProfile = async (name: string, profile: ProfileInput): Promise<Profile> => {
+  const response = await api.put<Profile>(`/api/profiles/${encodeURIComponent(name)}`, profile);
+  return response.data;
+};
+
+export const deleteProfile = async (name: string): Promise<void> => {
+  await api.delete(`/api/profiles/${encodeURIComponent(name)}`);
+};
@@ -0,0 +1,43 @@
+import type { Bullet, Resume } from '../types/resume';
+
+// bulletSelection encodes a bullet's chosen wording as "id:variant"
+const bulletSelection = (bullet: Bullet) => (bullet.variant ? `${bullet.id}:${bullet.variant}` : bullet.id);
+
+// buildSelections collects the selected item IDs in the shape the generate API expects
+export const buildSelections = (resume: Resume): Record<string, string[]> => {
+  const selectedSkills = resume.skills
+    .flatMap((cat) => cat.items.filter((s) => s.selected).map((s) => s.id));
+  const selectedBullets: string[] = [];
+  const selectedExperience: string[] = [];
+  const selectedProjects: string[] = [];
+
+  resume.experience.forEach((entry) => {
+    if (entry.selected) {
+      selectedExperience.push(entry.id);
+      entry.bullets.filter((b) => b.selected).forEach((b) => selectedBullets.push(bulletSelection(b)));
+    }
+  });
+
+  resume.projects.forEach((entry) => {
+    if (entry.selected) {
+      selectedProjects.push(entry.id);
+      entry.bullets.filter((b) => b.selected).forEach((b) => selectedBullets.push(bulletSelection(b)));
+    }
+  });
+
+  const selectedLeadership = resume.leadership
+    .filter((l) => l.selected)
+    .map((l) => l.id);
+
+  const selectedCustomItems = resume.custom_sections
+    .flatMap((section) => section.items.filter((i) => i.selected).map((i) => i.id));
+
+  return {
+    skill_ids: selectedSkills,
+    custom_item_ids: selectedCustomItems,
+    experience_ids: selectedExperience,
+    bullet_ids: selectedBullets,
+    project_ids: selectedProjects,
+    leadership_ids: selectedLeadership,
+  };
+};
@@ -1,4 +1,5 @@
 export interface SkillItem {
+  id: string;
   name: string;
   tags: string[];
   selected: boolean;
@@ -6,6 +7,7 @@ export interface SkillItem {
 
 export interface SkillCategory {
   category: string;
+  label: string;
   items: SkillItem[];
 }
 
@@ -13,7 +15,10 @@ export interface Bullet {
   id: string;
   text: string;
   tags: string[];
+  variants?: Record<string, string>;
   selected: boolean;
+  // variant is the wording chosen for this bullet; unset uses the default
+  variant?: string;
   relevanceScore?: number;
 }
 
@@ -24,9 +29,21 @@ export interface ExperienceEntry {
   location: string;
   start_date: string;
   end_date: string | null;
+  duration?: string;
   tags: string[];
   bullets: Bullet[];
   selected: boolean;
+  positions: Position[];
+}
+
+export interface Position {
+  id: string;
+  title: string;
+  location?: string;
+  start_date: string;
+  end_date: string | null;
+  duration?: string;
+  bullet_ids: string[];
 }
 
 export interface ProjectEntry {
@@ -40,6 +57,7 @@ export interface ProjectEntry {
 }
 
 export interface EducationEntry {
+  id: string;
   institution: string;
   location: string;
   degree: string;
@@ -48,6 +66,33 @@ export interface EducationEntry {
   gpa?: string;
   honors?: string;
   program?: string;
+  start_date?: string;
+  end_date?: string;
+  tags: string[];
+  coursework: Bullet[];
+  selected: boolean;
+}
+
+export type CustomSectionLayout = 'list' | 'entries' | 'table';
+
+export interface CustomItem {
+  id: string;
+  text?: string;
+  title?: string;
+  subtitle?: string;
+  date?: string;
+  key?: string;
+  value?: string;
+  tags: string[];
+  selected: boolean;
+  relevanceScore?: number;
+}
+
+export interface CustomSection {
+  id: string;
+  title: string;
+  layout: CustomSectionLayout;
+  items: CustomItem[];
 }
 
 export interface LeadershipEntry {
@@ -65,13 +110,24 @@ export interface ContactInfo {
   github?: string;
 }
 
+export type ResumeSectionName =
+  | 'summary'
+  | 'education'
+  | 'skills'
+  | 'experience'
+  | 'projects'
+  | 'leadership';
+
 export interface Resume {
+  // Built-in section names and custom section IDs, in render order
+  sections: (ResumeSectionName | string)[];
   contact: ContactInfo;
-  education: EducationEntry;
+  education: EducationEntry[];
   skills: SkillCategory[];
   experience: ExperienceEntry[];
   projects: ProjectEntry[];
   leadership: LeadershipEntry[];
+  custom_sections: CustomSection[];
 }
 
 export interface JobAnalysisResponse {
@@ -81,15 +137,93 @@ export interface JobAnalysisResponse {
 }
 
 export interface SectionOrder {
+  sections?: (ResumeSectionName | string)[];
+  education?: string[];
   experience: string[];
   projects: string[];
   leadership: string[];
+  bullets?: Record<string, string[]>;
 }
 
 export type SectionName = 'experience' | 'projects' | 'leadership';
 
 export interface PartialSectionOrder {
+  sections?: (ResumeSectionName | string)[];
+  education?: string[];
   experience?: string[];
   projects?: string[];
   leadership?: string[];
+  bullets?: Record<string, string[]>;
+}
+
+export interface Profile {
+  name: string;
+  description?: string;
+  ids?: string[];
+  tags?: string[];
+  query?: string;
+  skills?: string[];
+  order?: PartialSectionOrder;
+  template?: string;
+  variant?: string;
+  // selected_ids and bullet_variants resolve the profile against the current resume
+  selected_ids: string[];
+  bullet_variants?: Record<string, string>;
+}
+
+export type ProfileInput = Omit<Profile, 'name' | 'selected_ids' | 'bullet_variants'>;
+
+// ReloadEvent is pushed by /api/events when resume.yaml, order.yaml or
+// tags.yaml change on disk
+export interface ReloadEvent {
+  files?: string[];
+  ok: boolean;
+  file?: string;
+  line?: number;
+  error?: string;
+  etag?: string;
+}
+
+// Inputs for the editing API; omitted fields are left unchanged on update
+export interface BulletInput {
+  id?: string;
+  parent?: string;
+  text?: string;
+  tags?: string[];
+  variants?: Record<string, string>;
+  index?: number;
+}
+
+export interface ExperienceInput {
+  id?: string;
+  title?: string;
+  company?: string;
+  location?: string;
+  start_date?: string;
+  end_date?: string;
+  tags?: string[];
+  index?: number;
+}
+
+export interface ProjectInput {
+  id?: string;
+  title?: string;
+  technologies?: string;
+  github?: string;
+  tags?: string[];
+  index?: number;
+}
+
+export interface LeadershipInput {
+  id?: string;
+  text?: string;
+  tags?: string[];
+  index?: number;
+}
+
+export interface SkillInput {
+  id?: string;
+  category?: string;
+  name?: string;
+  tags?: string[];
 }